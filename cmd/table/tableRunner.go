@@ -5,11 +5,10 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/driver/desktop"
 )
 
 func main() {
-	myApp := app.New()
+	myApp := app.NewWithID("com.github.hooperbloob.fyne-components.table") // the ID keeps the preferences, like the table layouts
 	myWindow := myApp.NewWindow("Generic Table Demo")
 	myWindow.Resize(fyne.NewSize(500, 200))
 
@@ -20,19 +19,9 @@ func main() {
 
 	tableContainer.AdjustColumns()
 
-	myWindow.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) { // Set up keyboard handling for Delete/Backspace
-		tableContainer.HandleKeyboard(event)
-	})
-
-	CtrlC := &desktop.CustomShortcut{fyne.KeyC, fyne.KeyModifierControl}
-	myWindow.Canvas().AddShortcut(CtrlC, func(_ fyne.Shortcut) {
-		tableContainer.CopySelectionToClipboard(myApp)
-	})
-
-	CtrlA := &desktop.CustomShortcut{fyne.KeyA, fyne.KeyModifierControl}
-	myWindow.Canvas().AddShortcut(CtrlA, func(_ fyne.Shortcut) {
-		tableContainer.SelectAll()
-	})
+	if err := tableContainer.InstallKeymap(myWindow, nil); err != nil {
+		fyne.LogError("Installing the table shortcuts", err)
+	}
 
 	myWindow.ShowAndRun()
 }
@@ -1,7 +1,6 @@
 package domains
 
 import (
-	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -12,27 +11,16 @@ import (
 	"time"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/widget"
 )
 
 type File struct {
-	Name   string
-	Time   time.Time
-	Folder string
-	Size   int64
+	Name   string    `meta:"width=300,editable,collate=en,order=3"`
+	Time   time.Time `meta:"width=130,format=2006 01 02150405,order=2"`
+	Folder string    `meta:"width=200,editable,order=4"`
+	Size   int64     `meta:"width=130,order=1"`
 }
 
 var fileStatusField = meta.NewFieldDescriptor("?", func(f File) string { return f.Name }, nil, nil)
-var fileNameField = meta.NewFieldDescriptor("Name", func(f File) string { return f.Name }, nil, nil)
-var fileTimeField = meta.NewFieldDescriptor("Time", func(f File) string { return f.Time.Format("2006 01 02150405") }, nil, nil)
-var fileSizeField = meta.NewFieldDescriptor("Size", func(f File) string { return fmt.Sprintf("%d", f.Size) }, nil, nil)
-
-var fileColumns = []table.Column[File]{
-	table.NewColumn(130, fileSizeField, fyne.TextAlignTrailing, nil),
-	table.NewColumn(130, fileTimeField, fyne.TextAlignLeading, nil),
-	table.NewColumn(300, fileNameField, fyne.TextAlignLeading, nil),
-}
 
 func expandPath(path string) (string, error) {
 
@@ -65,9 +53,10 @@ func FilesFrom(folder string) []*File {
 	var files []*File
 	for _, entry := range localFiles {
 		files = append(files, &File{
-			Name: entry.Name(),
-			Size: entry.Size(),
-			Time: entry.ModTime(),
+			Name:   entry.Name(),
+			Folder: path,
+			Size:   entry.Size(),
+			Time:   entry.ModTime(),
 		})
 	}
 
@@ -80,38 +69,16 @@ func SetupFileTable(window fyne.Window, folder string) *table.TableContainer[Fil
 		return File{}
 	}
 
+	fileColumns, err := table.ColumnsFor[File]()
+	if err != nil {
+		log.Fatalf("File columns: %v", err)
+	}
 	gTable := table.NewGenericTable(fileColumns, newFileFunc)
 
-	gTable.SetData(FilesFrom(folder))
+	go func() { // scanning a large folder takes a while, the rows appear once it is done
+		gTable.SetData(FilesFrom(folder))
+	}()
 
-	editFileFunc := func(file *File, isAdd bool, idx int, callback func(File)) {
-		nameEntry := widget.NewEntry()
-		nameEntry.SetText(file.Name)
-
-		folderEntry := widget.NewEntry()
-		folderEntry.SetText(file.Folder)
-
-		formItems := []*widget.FormItem{
-			{Text: "Name", Widget: nameEntry},
-			{Text: "Folder", Widget: folderEntry},
-		}
-
-		var title = "Edit File"
-		if isAdd {
-			title = "Add File"
-		}
-
-		dialog.ShowForm(title, "Save", "Cancel", formItems, func(confirmed bool) {
-			if confirmed {
-
-				edited := File{
-					Name:   nameEntry.Text,
-					Folder: folderEntry.Text,
-				}
-				callback(edited)
-			}
-		}, window)
-	}
 	// customFunctions := []table.ItemAction[Person]{
 	// 	{
 	// 		Label:   "E",
@@ -121,5 +88,5 @@ func SetupFileTable(window fyne.Window, folder string) *table.TableContainer[Fil
 	// 	},
 	// }
 
-	return table.NewTableContainer(gTable, window, editFileFunc, nil) // Create the container with controls
+	return table.NewTableContainer(gTable, window, nil, nil) // Create the container with controls
 }
@@ -1,24 +1,27 @@
 package domains
 
 import (
+	"context"
 	"fmt"
 	"image/color"
-	"strconv"
+	"log"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hooperbloob/fyne-components/meta"
 	"github.com/hooperbloob/fyne-components/table"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
-	"fyne.io/fyne/v2/widget"
 )
 
 type Person struct {
-	Name       string
-	Email      string
-	Age        int
-	EmailsSent int
+	Name       string `meta:"width=120,editable,collate=en,order=2"`
+	Email      string `meta:"label=EMail,width=190,editable,order=3"`
+	Age        int    `meta:"width=40,editable,order=1"`
+	EmailsSent int    `meta:"label=Emails,width=30,order=4"`
 }
 
 var people = []*Person{
@@ -37,98 +40,112 @@ var statusField = meta.NewFieldDescriptor("?", func(p Person) string {
 		return "y "
 	}
 }, nil, nil)
-var personNameField = meta.NewFieldDescriptor("Name", func(p Person) string { return p.Name }, nil, nil)
-var personEmailField = meta.NewFieldDescriptor("EMail", func(p Person) string { return p.Email }, nil, nil)
-var personAgeField = meta.NewFieldDescriptor("Age", func(p Person) string { return fmt.Sprintf("%d", p.Age) }, nil, func(a, b Person) bool { return a.Age < b.Age })
-var personEmailsField = meta.NewFieldDescriptor("Emails", func(p Person) string { return fmt.Sprintf("%d", p.EmailsSent) }, nil, func(a, b Person) bool { return a.EmailsSent < b.EmailsSent })
+var noEmailStatus = table.Status{Shape: table.StatusTriangle, Color: color.NRGBA{R: 240, G: 80, B: 0, A: 255}, Tooltip: "No email address"}
 
-var colorSetter = func(person Person) color.Color {
+var statusOf = func(person Person) table.Status {
 
 	if person.Email == "" {
-		return color.NRGBA{R: 240, G: 80, B: 0, A: 255}
+		return noEmailStatus
 	} else {
-		return color.Transparent
+		return table.Status{}
 	}
 }
 
-var personColumns = []table.Column[Person]{
-	table.NewColumn(40, statusField, fyne.TextAlignTrailing, colorSetter),
-	table.NewColumn(40, personAgeField, fyne.TextAlignTrailing, nil),
-	table.NewColumn(120, personNameField, fyne.TextAlignLeading, nil),
-	table.NewColumn(190, personEmailField, fyne.TextAlignLeading, nil),
-	table.NewColumn(30, personEmailsField, fyne.TextAlignTrailing, nil),
-}
+// personColumns are the status column plus the ones described by the Person struct tags
+func personColumns() ([]table.Column[Person], error) {
 
-var ageValidator = func(s string) error {
-	if s == "" {
-		return fmt.Errorf("Age is required")
-	}
-	age, err := strconv.Atoi(s)
+	fields, err := meta.DescriptorsFor[Person]()
 	if err != nil {
-		return fmt.Errorf("Age must be a number")
+		return nil, err
+	}
+	meta.FieldNamed(fields, "Name").Validator = nameValidator
+	meta.FieldNamed(fields, "Age").Validator = ageValidator
+
+	status := table.NewStatusColumn(40, statusField, fyne.TextAlignCenter, statusOf)
+	columns := append([]table.Column[Person]{status}, table.ColumnsFrom(fields)...)
+
+	// the emails sent are drawn as a chip, grey until one was sent
+	emails := slices.IndexFunc(columns, func(col table.Column[Person]) bool { return col.ID() == "EmailsSent" })
+	if emails >= 0 {
+		columns[emails] = columns[emails].WithRenderer(table.NewBadgeRenderer(func(p Person) color.Color {
+			if p.EmailsSent == 0 {
+				return theme.Color(theme.ColorNameDisabled)
+			}
+			return theme.Color(theme.ColorNamePrimary)
+		}))
 	}
-	if age < 0 || age > 150 {
+	return columns, nil
+}
+
+var nameValidator = func(p Person) error {
+	if p.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	return nil
+}
+
+var ageValidator = func(p Person) error {
+	if p.Age < 0 || p.Age > 150 {
 		return fmt.Errorf("Age must be between 0 and 150")
 	}
 	return nil
 }
 
+type personValidator struct{}
+
+func (personValidator) Validate(p Person) error {
+	if p.Email != "" && !strings.Contains(p.Email, "@") {
+		return fmt.Errorf("EMail for %s is not a valid address", p.Name)
+	}
+	return nil
+}
+
 func SetupPeopleTable(window fyne.Window) *table.TableContainer[Person] {
 
 	newPersonFunc := func() Person { // Function to create a new empty Person
 		return Person{}
 	}
 
-	gTable := table.NewGenericTable(personColumns, newPersonFunc)
+	columns, err := personColumns()
+	if err != nil {
+		log.Fatalf("Person columns: %v", err)
+	}
+	gTable := table.NewGenericTable(columns, newPersonFunc)
 
 	gTable.SetData(people)
+	gTable.SetValidator(personValidator{})
+	gTable.PersistLayout("people")
+	gTable.WithZebra(true).WithStyleRules(
+		table.HeatMapRule("Age", func(p Person) float64 { return float64(p.Age) }, 0, 100,
+			color.NRGBA{R: 80, G: 160, B: 255, A: 255}, color.NRGBA{R: 255, G: 120, B: 60, A: 255}),
+		table.StyleRule[Person]{When: func(p Person) bool { return p.Age < 18 }, Style: table.CellStyle{Italic: true}},
+	)
 
-	editPersonFunc := func(person *Person, isAdd bool, idx int, callback func(Person)) {
-		nameEntry := widget.NewEntry()
-		nameEntry.SetText(person.Name)
-
-		emailEntry := widget.NewEntry()
-		emailEntry.SetText(person.Email)
-
-		ageEntry := widget.NewEntry()
-		ageEntry.SetText(fmt.Sprintf("%d", person.Age))
-		ageEntry.Validator = ageValidator
-
-		formItems := []*widget.FormItem{
-			{Text: "Name", Widget: nameEntry},
-			{Text: "Email", Widget: emailEntry},
-			{Text: "Age", Widget: ageEntry},
-		}
-
-		var title = "Edit Person"
-		if isAdd {
-			title = "Add Person"
-		}
-
-		dialog.ShowForm(title, "Save", "Cancel", formItems, func(confirmed bool) {
-			if confirmed {
-				var age int
-				fmt.Sscanf(ageEntry.Text, "%d", &age)
-
-				edited := Person{
-					Name:  nameEntry.Text,
-					Email: emailEntry.Text,
-					Age:   age,
-				}
-				callback(edited)
-			}
-		}, window)
-	}
 	customFunctions := []table.ItemAction[Person]{
 		{
-			Label:   "E",
-			Icon:    theme.MailSendIcon(),
-			Action:  func(people []*Person) bool { return sendEmailFor(people) },
-			Enabler: func(people []*Person) bool { return len(people[0].Email) > 0 }, // TODO loop through & check all
+			Label:    "E",
+			Icon:     theme.MailSendIcon(),
+			Action:   func(people []*Person) bool { return sendEmailFor(people) },
+			Enabler:  func(people []*Person) bool { return len(people[0].Email) > 0 }, // TODO loop through & check all
+			Shortcut: &desktop.CustomShortcut{KeyName: fyne.KeyM, Modifier: fyne.KeyModifierShortcutDefault},
+		},
+		{
+			Label:     "Verify",
+			Icon:      theme.MailComposeIcon(),
+			Async:     verifyEmailsOf,
+			StatusBar: true,
+		},
+		{Separator: true},
+		{
+			Label: "Emails",
+			Submenu: []table.ItemAction[Person]{
+				{Label: "Reset count", Action: resetEmailsSent},
+			},
 		},
 	}
 
-	return table.NewTableContainer(gTable, window, editPersonFunc, customFunctions) // Create the container with controls
+	tableContainer := table.NewTableContainer(gTable, window, nil, customFunctions) // Create the container with controls
+	return tableContainer.WithLegend(table.LegendEntry{Status: noEmailStatus, Meaning: "no email address"})
 }
 
 func sendEmailFor(people []*Person) bool {
@@ -139,3 +156,30 @@ func sendEmailFor(people []*Person) bool {
 	}
 	return true
 }
+
+func resetEmailsSent(people []*Person) bool {
+	for _, person := range people {
+		person.EmailsSent = 0
+	}
+	return true
+}
+
+// verifyEmailsOf pretends to contact the mail server of every person, which takes a while
+func verifyEmailsOf(ctx context.Context, people []*Person, progress *table.Progress[Person]) error {
+
+	for _, person := range people {
+		progress.SetStatus("Verifying " + person.Name)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(300 * time.Millisecond):
+		}
+
+		if !strings.Contains(person.Email, "@") {
+			progress.Done(person, fmt.Errorf("No valid address"))
+			continue
+		}
+		progress.Done(person, nil)
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ module github.com/hooperbloob/fyne-components
 
 go 1.25.1
 
-require fyne.io/fyne/v2 v2.7.2
+require (
+	fyne.io/fyne/v2 v2.7.2
+	golang.org/x/text v0.22.0
+)
 
 require (
 	fyne.io/systray v1.12.0 // indirect
@@ -35,6 +38,5 @@ require (
 	golang.org/x/image v0.24.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
-	golang.org/x/text v0.22.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,10 +1,18 @@
 package meta
 
+import (
+	"fmt"
+)
+
 type FieldDescriptor[T any] struct {
 	Label     string
 	Accessor  func(T) string
-	Validator func(T) error     // field-specific validation
-	lessThan  func(a, b T) bool // optional, use if the string values aren't reliable for sorting.. i.e  numbers, dates, etc
+	Validator func(T) error          // field-specific validation
+	lessThan  func(a, b T) bool      // optional, use if the string values aren't reliable for sorting.. i.e  numbers, dates, etc
+	setter    func(*T, string) error // optional, parses the text and stores it in the field, nil if read-only
+	noSort    bool
+	value     func(T) any         // optional, the typed value for exports that keep types
+	setValue  func(*T, any) error // optional, stores a typed value
 }
 
 func NewFieldDescriptor[T any](label string, accessor func(T) string, validator func(T) error, lessThan func(a, b T) bool) *FieldDescriptor[T] {
@@ -17,6 +25,66 @@ func NewFieldDescriptor[T any](label string, accessor func(T) string, validator
 	}
 }
 
+// WithSetter makes the field editable, the setter parses the text into the item's field
+func (fd *FieldDescriptor[T]) WithSetter(setter func(item *T, value string) error) *FieldDescriptor[T] {
+	fd.setter = setter
+	return fd
+}
+
+func (fd *FieldDescriptor[T]) IsEditable() bool {
+	return fd.setter != nil
+}
+
+func (fd *FieldDescriptor[T]) SetFromString(item *T, value string) error {
+
+	if fd.setter == nil {
+		return fmt.Errorf("%s is not editable", fd.Label)
+	}
+	return fd.setter(item, value)
+}
+
+// WithValue exposes the typed value of the field, so numbers and dates survive exports and imports.
+// The setter may be nil for read-only fields.
+func (fd *FieldDescriptor[T]) WithValue(getter func(T) any, setter func(*T, any) error) *FieldDescriptor[T] {
+	fd.value = getter
+	fd.setValue = setter
+	return fd
+}
+
+// Value returns the typed value if there is one, otherwise the string value
+func (fd *FieldDescriptor[T]) Value(item T) any {
+
+	if fd.value != nil {
+		return fd.value(item)
+	}
+	return fd.Accessor(item)
+}
+
+// SetValue stores a typed value, going through the string setter if there's no typed one
+func (fd *FieldDescriptor[T]) SetValue(item *T, value any) error {
+
+	if fd.setValue != nil {
+		return fd.setValue(item, value)
+	}
+	if value == nil {
+		return fd.SetFromString(item, "")
+	}
+	if s, ok := value.(string); ok {
+		return fd.SetFromString(item, s)
+	}
+	return fd.SetFromString(item, fmt.Sprint(value))
+}
+
+// WithoutSorting stops the field from being used as a sort key
+func (fd *FieldDescriptor[T]) WithoutSorting() *FieldDescriptor[T] {
+	fd.noSort = true
+	return fd
+}
+
+func (fd *FieldDescriptor[T]) IsSortable() bool {
+	return !fd.noSort
+}
+
 func (fd *FieldDescriptor[T]) StringValueFor(item T) string {
 	return fd.Accessor(item)
 }
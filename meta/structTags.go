@@ -0,0 +1,349 @@
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Alignment int
+
+const (
+	AlignLeading Alignment = iota
+	AlignCenter
+	AlignTrailing
+)
+
+// TaggedField is a field descriptor plus the presentation hints read from its struct tag
+type TaggedField[T any] struct {
+	*FieldDescriptor[T]
+	Name   string // the struct field name
+	Width  int
+	Align  Alignment
+	Hidden bool
+	Order  int // position among the fields, 0 for declaration order after the numbered ones
+}
+
+const TagName = "meta"
+
+const defaultWidth = 100
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+// DescriptorsFor builds descriptors for the exported fields of a struct from their `meta` tags, e.g.
+//
+//	Age   int       `meta:"label=Years,width=40,align=trailing,editable,order=1"`
+//	Born  time.Time `meta:"format=2006-01-02,sortable=false"`
+//	Score float64   `meta:"printf=%.1f"`
+//	City  string    `meta:"collate=de"`
+//	Key   string    `meta:"hidden"`
+//	Temp  string    `meta:"-"`
+//
+// Untagged fields get their name as label. Fields with an order come first, by it, the others follow
+// as declared. Accessors, comparators and setters follow the field's type: ints, uints, floats, bools,
+// time.Time and fmt.Stringers are formatted and ordered by value, text by byte order unless a language
+// is given with collate=<lang>. format is the layout of a time, printf the verb for other values.
+// Stringers other than time.Time can't be parsed back and stay read-only even if tagged editable.
+func DescriptorsFor[T any]() ([]*TaggedField[T], error) {
+
+	structType := reflect.TypeFor[T]()
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", structType)
+	}
+
+	var fields []*TaggedField[T]
+	for _, sf := range reflect.VisibleFields(structType) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get(TagName)
+		if tag == "-" {
+			continue
+		}
+		field, err := taggedField[T](sf, tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", structType.Name(), sf.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	slices.SortStableFunc(fields, func(a, b *TaggedField[T]) int {
+		switch {
+		case a.Order == b.Order:
+			return 0
+		case a.Order == 0:
+			return 1
+		case b.Order == 0:
+			return -1
+		}
+		return a.Order - b.Order
+	})
+	return fields, nil
+}
+
+// FieldNamed finds the descriptor of the named struct field, nil if there isn't one
+func FieldNamed[T any](fields []*TaggedField[T], name string) *TaggedField[T] {
+
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func taggedField[T any](sf reflect.StructField, tag string) (*TaggedField[T], error) {
+
+	label := sf.Name
+	width, order := defaultWidth, 0
+	format, verb, collation := "", "", ""
+	sortable, editable, hidden := true, false, false
+	align, alignSet := AlignLeading, false
+
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(option, "=")
+
+		var err error
+		switch key {
+		case "label":
+			label = value
+		case "width":
+			width, err = strconv.Atoi(value)
+		case "align":
+			align, err = alignmentOf(value)
+			alignSet = true
+		case "format":
+			if sf.Type != timeType {
+				err = fmt.Errorf("format is the layout of a time, use printf for a %s", sf.Type)
+			}
+			format = value
+		case "printf":
+			verb = value
+		case "order":
+			order, err = strconv.Atoi(value)
+		case "collate":
+			collation = value
+		case "sortable":
+			sortable, err = boolOption(value, hasValue)
+		case "editable":
+			editable, err = boolOption(value, hasValue)
+		case "hidden":
+			hidden, err = boolOption(value, hasValue)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index := sf.Index
+	valueOf := func(item T) reflect.Value {
+		return reflect.ValueOf(item).FieldByIndex(index)
+	}
+
+	kind := sf.Type.Kind()
+	if !alignSet && isNumeric(kind) {
+		align = AlignTrailing
+	}
+
+	format2str := formatterFor(sf.Type, format, verb)
+	accessor := func(item T) string { return format2str(valueOf(item)) }
+
+	compare := orderingFor(sf.Type)
+	if collation != "" {
+		collated := CollatedCompare(collation)
+		compare = func(a, b reflect.Value) int { return collated(textOf(a), textOf(b)) }
+	}
+	lessThan := func(a, b T) bool { return compare(valueOf(a), valueOf(b)) < 0 }
+
+	descriptor := NewFieldDescriptor(label, accessor, nil, lessThan)
+	if !sortable {
+		descriptor.WithoutSorting()
+	}
+
+	getter := func(item T) any { return valueOf(item).Interface() }
+
+	parse := parserFor(sf.Type, format)
+	if editable && parse == nil && sf.Type.Implements(stringerType) {
+		editable = false // shown by String(), which can't be parsed back
+	}
+
+	if !editable {
+		descriptor.WithValue(getter, nil)
+	} else {
+		if parse == nil {
+			return nil, fmt.Errorf("fields of type %s can't be edited", sf.Type)
+		}
+		descriptor.WithSetter(func(item *T, text string) error {
+			value, err := parse(text)
+			if err != nil {
+				return fmt.Errorf("%s must be %s", label, expectedInput(sf.Type, format))
+			}
+			reflect.ValueOf(item).Elem().FieldByIndex(index).Set(value)
+			return nil
+		})
+		descriptor.WithValue(getter, func(item *T, v any) error {
+			if s, ok := v.(string); ok {
+				return descriptor.SetFromString(item, s)
+			}
+			value, err := convertValue(v, sf.Type)
+			if err != nil {
+				return fmt.Errorf("%s must be %s", label, expectedInput(sf.Type, format))
+			}
+			reflect.ValueOf(item).Elem().FieldByIndex(index).Set(value)
+			return nil
+		})
+	}
+
+	return &TaggedField[T]{
+		FieldDescriptor: descriptor,
+		Name:            sf.Name,
+		Width:           width,
+		Align:           align,
+		Hidden:          hidden,
+		Order:           order,
+	}, nil
+}
+
+func alignmentOf(value string) (Alignment, error) {
+
+	switch value {
+	case "leading", "left":
+		return AlignLeading, nil
+	case "center":
+		return AlignCenter, nil
+	case "trailing", "right":
+		return AlignTrailing, nil
+	}
+	return AlignLeading, fmt.Errorf("unknown alignment %q", value)
+}
+
+// boolOption treats a bare option as true
+func boolOption(value string, hasValue bool) (bool, error) {
+	if !hasValue {
+		return true, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// formatterFor shows times with the layout, other values with the printf verb
+func formatterFor(typ reflect.Type, format, verb string) func(reflect.Value) string {
+
+	switch {
+	case typ == timeType:
+		if format == "" {
+			format = time.DateTime
+		}
+		return func(v reflect.Value) string {
+			t := v.Interface().(time.Time)
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(format)
+		}
+	case verb != "":
+		return func(v reflect.Value) string { return fmt.Sprintf(verb, v.Interface()) }
+	case typ.Implements(stringerType):
+		return func(v reflect.Value) string { return v.Interface().(fmt.Stringer).String() }
+	case isInt(typ.Kind()):
+		return func(v reflect.Value) string { return strconv.FormatInt(v.Int(), 10) }
+	case isUint(typ.Kind()):
+		return func(v reflect.Value) string { return strconv.FormatUint(v.Uint(), 10) }
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		return func(v reflect.Value) string { return strconv.FormatFloat(v.Float(), 'f', -1, typ.Bits()) }
+	case typ.Kind() == reflect.Bool:
+		return func(v reflect.Value) string { return strconv.FormatBool(v.Bool()) }
+	case typ.Kind() == reflect.String:
+		return func(v reflect.Value) string { return v.String() }
+	}
+	return func(v reflect.Value) string { return fmt.Sprint(v.Interface()) }
+}
+
+func expectedInput(typ reflect.Type, format string) string {
+
+	kind := typ.Kind()
+	switch {
+	case typ == timeType:
+		if format == "" {
+			format = time.DateTime
+		}
+		return "a time like " + format
+	case isInt(kind) || isUint(kind):
+		return "a whole number"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "a number"
+	case kind == reflect.Bool:
+		return "true or false"
+	}
+	return "a " + typ.String()
+}
+
+// parserFor converts text to a value of the field's type, nil if the type can't be parsed.
+// Stringers aren't, their text needn't be what their kind parses.
+func parserFor(typ reflect.Type, format string) func(string) (reflect.Value, error) {
+
+	kind := typ.Kind()
+	switch {
+	case typ == timeType:
+		if format == "" {
+			format = time.DateTime
+		}
+		return func(text string) (reflect.Value, error) {
+			if text == "" {
+				return reflect.Zero(typ), nil
+			}
+			t, err := time.Parse(format, text)
+			return reflect.ValueOf(t), err
+		}
+	case typ.Implements(stringerType):
+		return nil
+	case isInt(kind):
+		return func(text string) (reflect.Value, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(text), 10, typ.Bits())
+			return reflect.ValueOf(n).Convert(typ), err
+		}
+	case isUint(kind):
+		return func(text string) (reflect.Value, error) {
+			n, err := strconv.ParseUint(strings.TrimSpace(text), 10, typ.Bits())
+			return reflect.ValueOf(n).Convert(typ), err
+		}
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return func(text string) (reflect.Value, error) {
+			f, err := strconv.ParseFloat(strings.TrimSpace(text), typ.Bits())
+			return reflect.ValueOf(f).Convert(typ), err
+		}
+	case kind == reflect.Bool:
+		return func(text string) (reflect.Value, error) {
+			b, err := strconv.ParseBool(strings.TrimSpace(text))
+			return reflect.ValueOf(b).Convert(typ), err
+		}
+	case kind == reflect.String:
+		return func(text string) (reflect.Value, error) {
+			return reflect.ValueOf(text).Convert(typ), nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package meta
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+type NullOrder int
+
+const (
+	NullsFirst NullOrder = iota
+	NullsLast
+)
+
+// TypedField describes a field by its value rather than its text, so ordering follows the value's type:
+// numbers numerically, times chronologically, bools false first and strings by byte order or a collation.
+// Nil pointers, and zero values if asked, are nulls that sort together at one end.
+type TypedField[T any, V any] struct {
+	label      string
+	value      func(T) V
+	format     func(V) string
+	compare    func(a, b V) int
+	zeroIsNull bool
+	nulls      NullOrder
+	validator  func(T) error
+	parse      func(string) (V, error)
+	set        func(*T, V)
+	noSort     bool
+}
+
+// NewTypedField takes the field's value getter and formatter, a nil formatter prints the value as is
+func NewTypedField[T any, V any](label string, value func(T) V, format func(V) string) *TypedField[T, V] {
+
+	order := orderingFor(reflect.TypeFor[V]())
+
+	return &TypedField[T, V]{
+		label:   label,
+		value:   value,
+		format:  format,
+		compare: func(a, b V) int { return order(reflect.ValueOf(a), reflect.ValueOf(b)) },
+	}
+}
+
+// WithCompare overrides the ordering derived from the value type
+func (tf *TypedField[T, V]) WithCompare(compare func(a, b V) int) *TypedField[T, V] {
+	tf.compare = compare
+	return tf
+}
+
+// WithCollation orders string values by the conventions of the language, e.g. "en", "de", "sv"
+func (tf *TypedField[T, V]) WithCollation(lang string) *TypedField[T, V] {
+
+	collated := CollatedCompare(lang)
+	tf.compare = func(a, b V) int {
+		return collated(textOf(reflect.ValueOf(a)), textOf(reflect.ValueOf(b)))
+	}
+	return tf
+}
+
+// WithNulls places the null values at the start or end of an ascending sort
+func (tf *TypedField[T, V]) WithNulls(order NullOrder) *TypedField[T, V] {
+	tf.nulls = order
+	return tf
+}
+
+// TreatZeroAsNull counts zero values, e.g. 0, "" or the zero time, as nulls
+func (tf *TypedField[T, V]) TreatZeroAsNull() *TypedField[T, V] {
+	tf.zeroIsNull = true
+	return tf
+}
+
+func (tf *TypedField[T, V]) WithValidator(validator func(T) error) *TypedField[T, V] {
+	tf.validator = validator
+	return tf
+}
+
+// WithSetter makes the field editable, the parser converts text to a value that the setter stores
+func (tf *TypedField[T, V]) WithSetter(parse func(string) (V, error), set func(*T, V)) *TypedField[T, V] {
+	tf.parse = parse
+	tf.set = set
+	return tf
+}
+
+func (tf *TypedField[T, V]) WithoutSorting() *TypedField[T, V] {
+	tf.noSort = true
+	return tf
+}
+
+// Value returns the typed value of the field for the item
+func (tf *TypedField[T, V]) Value(item T) V {
+	return tf.value(item)
+}
+
+// Compare orders two items by their values, nulls placed as configured
+func (tf *TypedField[T, V]) Compare(a, b T) int {
+
+	va, vb := tf.value(a), tf.value(b)
+	nullA, nullB := tf.isNull(va), tf.isNull(vb)
+
+	switch {
+	case nullA && nullB:
+		return 0
+	case nullA || nullB:
+		result := -1 // nulls first
+		if tf.nulls == NullsLast {
+			result = 1
+		}
+		if nullB {
+			result = -result
+		}
+		return result
+	}
+	return tf.compare(va, vb)
+}
+
+func (tf *TypedField[T, V]) isNull(value V) bool {
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return tf.zeroIsNull && v.IsZero()
+}
+
+func (tf *TypedField[T, V]) text(item T) string {
+
+	value := tf.value(item)
+	if tf.format != nil {
+		return tf.format(value)
+	}
+	if tf.isNull(value) {
+		return ""
+	}
+	return textOf(reflect.ValueOf(value))
+}
+
+// Descriptor adapts the typed field to a FieldDescriptor for use in columns
+func (tf *TypedField[T, V]) Descriptor() *FieldDescriptor[T] {
+
+	lessThan := func(a, b T) bool { return tf.Compare(a, b) < 0 }
+	fd := NewFieldDescriptor(tf.label, tf.text, tf.validator, lessThan)
+
+	if tf.noSort {
+		fd.WithoutSorting()
+	}
+	getter := func(item T) any { return tf.value(item) }
+
+	if tf.parse == nil || tf.set == nil {
+		fd.WithValue(getter, nil)
+		return fd
+	}
+
+	fd.WithSetter(func(item *T, text string) error {
+		value, err := tf.parse(text)
+		if err != nil {
+			return err
+		}
+		tf.set(item, value)
+		return nil
+	})
+	fd.WithValue(getter, func(item *T, v any) error {
+		if s, ok := v.(string); ok {
+			return fd.SetFromString(item, s)
+		}
+		value, err := convertValue(v, reflect.TypeFor[V]())
+		if err != nil {
+			return fmt.Errorf("%s: %v", tf.label, err)
+		}
+		tf.set(item, value.Interface().(V))
+		return nil
+	})
+	return fd
+}
+
+// convertValue converts numbers between kinds, rounding into integers, and passes on anything assignable
+func convertValue(v any, typ reflect.Type) (reflect.Value, error) {
+
+	if v == nil {
+		return reflect.Zero(typ), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(typ) {
+		return rv, nil
+	}
+
+	from, to := rv.Kind(), typ.Kind()
+	if isNumeric(from) && isNumeric(to) {
+		if (isInt(to) || isUint(to)) && (from == reflect.Float32 || from == reflect.Float64) {
+			rv = reflect.ValueOf(math.Round(rv.Float()))
+		}
+		return rv.Convert(typ), nil
+	}
+	if rv.Type().ConvertibleTo(typ) && from == to {
+		return rv.Convert(typ), nil
+	}
+	if to == reflect.String { // e.g. zip codes or phone numbers stored as numbers
+		return reflect.ValueOf(textOf(rv)).Convert(typ), nil
+	}
+	return reflect.Value{}, fmt.Errorf("can't use a %T as %s", v, typ)
+}
+
+// ================= value ordering ================
+
+// orderingFor returns a three-way comparison for values of the type, falling back to their text
+func orderingFor(typ reflect.Type) func(a, b reflect.Value) int {
+
+	if typ == nil {
+		return func(a, b reflect.Value) int { return strings.Compare(textOf(a), textOf(b)) }
+	}
+
+	kind := typ.Kind()
+	switch {
+	case typ == timeType:
+		return func(a, b reflect.Value) int { return a.Interface().(time.Time).Compare(b.Interface().(time.Time)) }
+	case isInt(kind):
+		return func(a, b reflect.Value) int { return compareOrdered(a.Int(), b.Int()) }
+	case isUint(kind):
+		return func(a, b reflect.Value) int { return compareOrdered(a.Uint(), b.Uint()) }
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return func(a, b reflect.Value) int { return compareOrdered(a.Float(), b.Float()) }
+	case kind == reflect.Bool:
+		return func(a, b reflect.Value) int { return compareOrdered(boolRank(a.Bool()), boolRank(b.Bool())) }
+	case kind == reflect.String:
+		return func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) }
+	case kind == reflect.Pointer:
+		elemOrder := orderingFor(typ.Elem())
+		return func(a, b reflect.Value) int {
+			switch {
+			case a.IsNil() && b.IsNil():
+				return 0
+			case a.IsNil():
+				return -1
+			case b.IsNil():
+				return 1
+			}
+			return elemOrder(a.Elem(), b.Elem())
+		}
+	}
+	return func(a, b reflect.Value) int { return strings.Compare(textOf(a), textOf(b)) }
+}
+
+func compareOrdered[N int64 | uint64 | float64 | int](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// textOf renders a value for display, dereferencing pointers and leaving nils blank
+func textOf(v reflect.Value) string {
+
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.DateTime)
+	}
+	if kind := v.Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// CollatedCompare returns a comparison of strings following the language's collation rules, so accented
+// and mixed case text sorts the way its readers expect. Unknown languages fall back to the root collation.
+// The comparison is safe for concurrent use, tables sharing the field may sort at the same time.
+func CollatedCompare(lang string) func(a, b string) int {
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		tag = language.Und
+	}
+	collator := collate.New(tag)
+
+	var mu sync.Mutex // the collator keeps buffers between calls
+	return func(a, b string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return collator.CompareString(a, b)
+	}
+}
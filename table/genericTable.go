@@ -1,9 +1,10 @@
 package table
 
 import (
+	"fmt"
 	"image/color"
-	"sort"
-	"strings"
+	"slices"
+	"sync"
 
 	"github.com/hooperbloob/fyne-components/meta"
 
@@ -13,125 +14,317 @@ import (
 )
 
 type Column[T any] struct {
-	width         int
-	field         *meta.FieldDescriptor[T]
-	alignment     fyne.TextAlign
-	colorSelector func(T) color.Color
+	id        string // identifies the column in saved layouts and style rules, the label if empty
+	width     int
+	field     *meta.FieldDescriptor[T]
+	alignment fyne.TextAlign
+	status    func(T) Status  // set for status columns
+	renderer  CellRenderer[T] // draws the values rather than the label, nil if there is none
 }
 
+// IsIcon tells whether the column shows a status indicator rather than a value
 func (col *Column[T]) IsIcon() bool {
-	return col.colorSelector != nil
+	return col.status != nil
+}
+
+// WithID sets what identifies the column in saved layouts and style rules, so they survive changes
+// to its label or position. Columns built from struct tags have the field name.
+func (col Column[T]) WithID(id string) Column[T] {
+	col.id = id
+	return col
+}
+
+// ID identifies the column, its label unless set with WithID
+func (col *Column[T]) ID() string {
+	if col.id != "" {
+		return col.id
+	}
+	return col.field.Label
 }
 
 func (col *Column[T]) ColorFor(item T) color.Color {
-	return col.colorSelector(item)
+	return col.StatusFor(item).Color
 }
 
 func (col *Column[T]) StringValueFor(item T) string {
 	return col.field.StringValueFor(item)
 }
 
+// NewColumn shows the values of the field, or a circle of the colour the selector picks when there is one
 func NewColumn[T any](width int, field *meta.FieldDescriptor[T], valueAlignment fyne.TextAlign, colorSelector func(T) color.Color) Column[T] {
 
+	if colorSelector != nil {
+		return NewStatusColumn(width, field, valueAlignment, func(item T) Status {
+			return Status{Shape: StatusCircle, Color: colorSelector(item)}
+		})
+	}
 	return Column[T]{
-		width:         width,
-		field:         field,
-		alignment:     valueAlignment,
-		colorSelector: colorSelector,
+		width:     width,
+		field:     field,
+		alignment: valueAlignment,
+	}
+}
+
+// ColumnsFor builds the columns of a struct from its `meta` tags, see meta.DescriptorsFor
+func ColumnsFor[T any]() ([]Column[T], error) {
+
+	fields, err := meta.DescriptorsFor[T]()
+	if err != nil {
+		return nil, err
 	}
+	return ColumnsFrom(fields), nil
+}
+
+// ColumnsFrom turns tagged fields into columns, leaving out the hidden ones
+func ColumnsFrom[T any](fields []*meta.TaggedField[T]) []Column[T] {
+
+	var columns []Column[T]
+	for _, field := range fields {
+		if !field.Hidden {
+			columns = append(columns, NewColumn(field.Width, field.FieldDescriptor, textAlignOf(field.Align), nil).WithID(field.Name))
+		}
+	}
+	return columns
+}
+
+func textAlignOf(align meta.Alignment) fyne.TextAlign {
+
+	switch align {
+	case meta.AlignCenter:
+		return fyne.TextAlignCenter
+	case meta.AlignTrailing:
+		return fyne.TextAlignTrailing
+	}
+	return fyne.TextAlignLeading
 }
 
 // ========================================================================================================================================
 type GenericTable[T any] struct {
 	widget.BaseWidget
-	data         []*T
-	columns      []Column[T]
-	table        *widget.Table
-	selectedRows IntSet
-	newItemFunc  func() T
-	sortCol      int
-	sortAsc      bool
+	mu            sync.Mutex // guards the items, selection, sort and filter, changes may come from any goroutine
+	batchDepth    int
+	pending       pendingChanges
+	source        DataSource[T]
+	stopListening func()
+	pager         *pager[T] // set when the rows are loaded a page at a time
+	data          []*T      // the items fetched from the source
+	rows          []*T      // the items that pass the filter, in display order
+	filter        SourceFilter[T]
+	onError       func(error)
+	history       *History // records the changes made through the table, nil if not kept
+	changes       *changeTracker[T]
+	columns       []Column[T]
+	order         []int     // the shown columns from left to right, as indices into columns
+	widths        []float32 // per column
+	layoutID      string    // where the layout is saved in the app preferences, empty if it isn't
+	table         *navTable
+	cells         map[widget.TableCellID]*TableCell       // the cells currently on screen, used on the UI goroutine only
+	pools         map[CellRenderer[T]][]fyne.CanvasObject // the objects of the renderers not in use by a cell
+	rowHeight     float32
+	styleRules    []StyleRule[T]
+	zebra         bool
+	cursor        widget.TableCellID // the last cell selected or moved to
+	anchor        int                // the row ranges are selected from
+	moveHow       selectHow
+	selectionMode SelectionMode
+	editor        *cellEditor
+	validator     meta.Validator[T]
+	selection     *itemSet[T]
+	selListeners  []func([]*T)
+	rowMenu       func([]*T) *fyne.Menu
+	keyHandler    func(*fyne.KeyEvent) bool // gets the keys the table doesn't use
+	busy          func() bool               // blocks inline edits while true, set by the container
+	newItemFunc   func() T
+	sortKeys      []SortKey
 }
 
 func (gTable *GenericTable[T]) SetColumnWidths() {
 
-	for index, col := range gTable.columns {
-		gTable.table.SetColumnWidth(index, float32(col.width))
+	gTable.mu.Lock()
+	widths := make([]float32, len(gTable.order))
+	for pos, idx := range gTable.order {
+		widths[pos] = gTable.widths[idx]
+	}
+	gTable.mu.Unlock()
+
+	for pos, width := range widths {
+		gTable.table.SetColumnWidth(pos, width)
 	}
 }
 
 var selectionColor = color.NRGBA{R: 100, G: 150, B: 255, A: 80}
 
 func NewGenericTable[T any](columns []Column[T], newItemFunc func() T) *GenericTable[T] {
+	return NewGenericTableWithKey(columns, newItemFunc, nil)
+}
+
+// NewGenericTableWithKey identifies items by the key function when tracking selections so they survive
+// reloads with fresh instances via SetData. A nil function falls back to pointer identity.
+func NewGenericTableWithKey[T any](columns []Column[T], newItemFunc func() T, keyFunc func(*T) any) *GenericTable[T] {
 	gt := &GenericTable[T]{
-		columns:      columns,
-		newItemFunc:  newItemFunc,
-		selectedRows: IntSet{},
-		sortCol:      -1, // no sort column yet
+		columns:     columns,
+		newItemFunc: newItemFunc,
+		selection:   newItemSet(keyFunc),
+		changes:     newChangeTracker[T](),
+		cells:       map[widget.TableCellID]*TableCell{},
+		pools:       map[CellRenderer[T]][]fyne.CanvasObject{},
 	}
+	gt.resetColumnsLocked()
 
-	gt.table = widget.NewTable(
+	gt.table = newNavTable(
 		func() (int, int) {
-			return len(gt.data), len(gt.columns)
+			return gt.rowCount(), gt.shownCount()
 		},
 
 		func() fyne.CanvasObject {
-			return NewTableCell()
+			return gt.newCell()
 		},
 		func(id widget.TableCellID, obj fyne.CanvasObject) {
 			cell := obj.(*TableCell)
-			label := cell.label
 
-			column := gt.columns[id.Col]
-			item := gt.data[id.Row]
+			if gt.cells[cell.id] == cell { // the cell moved on from the row it showed
+				delete(gt.cells, cell.id)
+			}
+			cell.id = id
+			gt.cells[id] = cell
+
+			column := gt.shownColumn(id.Col)
+			item := gt.rowAt(id.Row)
+			selected := item != nil && gt.IsSelected(item)
 
-			label.SetText(column.StringValueFor((*item)))
-			label.Alignment = column.alignment
+			gt.renderCell(cell, column, item, selected)
 
-			if gt.selectedRows.Contains(id.Row) {
-				cell.bg.FillColor = theme.SelectionColor()
-			} else {
+			if item == nil || column == nil { // a placeholder until the page is loaded
+				cell.setText("…", fyne.TextAlignLeading, CellStyle{})
+				cell.setMark(nil)
 				cell.bg.FillColor = color.Transparent
+				cell.bg.Refresh()
+				return
 			}
-			cell.bg.Refresh()
 
-			if column.IsIcon() {
-				clr := column.ColorFor((*item))
-				cell.shape.FillColor = clr
-				cell.shape.Show()
-				cell.label.Hide()
-			} else {
-				cell.shape.Hide()
-				cell.label.Show()
+			style := gt.cellStyle(item, id.Row, column.ID())
+			if !column.HasRenderer() {
+				cell.setText(column.StringValueFor((*item)), column.alignment, style)
 			}
-			cell.shape.Refresh()
+
+			switch {
+			case selected:
+				cell.bg.FillColor = theme.SelectionColor()
+			case style.Background != nil:
+				cell.bg.FillColor = style.Background
+			default:
+				cell.bg.FillColor = color.Transparent
+			}
+			cell.bg.Refresh()
+			cell.setMark(gt.dirtyColor(item, column))
 		},
 	)
 
 	gt.setupHeaders()
 	gt.setupHandlers()
+	gt.SetDataSource(NewSliceSource[T](nil))
 
 	gt.ExtendBaseWidget(gt)
 	return gt
 }
 
-func (gt *GenericTable[T]) newSelectedRow(rowNum int) {
-	old := gt.selectedRows
-	gt.selectedRows = *NewIntSet(rowNum)
+// AddSelectionListener registers a function called with the selected items whenever the selection changes
+func (gt *GenericTable[T]) AddSelectionListener(listener func(selected []*T)) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.selListeners = append(gt.selListeners, listener)
+}
+
+// selectionChanged redraws the table and tells the listeners, on the UI goroutine
+func (gt *GenericTable[T]) selectionChanged() {
+
+	selected := gt.SelectedItems()
+	gt.mu.Lock()
+	listeners := slices.Clone(gt.selListeners)
+	gt.mu.Unlock()
+
+	fyne.Do(func() {
+		gt.table.Refresh()
+		for _, listener := range listeners {
+			listener(selected)
+		}
+	})
+}
+
+// redraw refreshes the table on the UI goroutine, whichever goroutine the change came from
+func (gt *GenericTable[T]) redraw() {
+	fyne.Do(gt.table.Refresh)
+}
+
+// unselectCells clears the table's own cell marker, which is tied to a row index
+func (gt *GenericTable[T]) unselectCells() {
+	fyne.Do(gt.table.UnselectAll)
+}
 
-	columnCount := len(gt.columns)
+// SelectedItems returns the visible selected items in display order.
+// When paged, the selected items no longer cached follow the others.
+func (gt *GenericTable[T]) SelectedItems() []*T {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.selectedLocked()
+}
 
-	// refresh previously selected row
-	for rowIdx := range old {
-		for col := 0; col < columnCount; col++ {
-			gt.table.RefreshItem(widget.TableCellID{Row: rowIdx, Col: col})
+func (gt *GenericTable[T]) selectedLocked() []*T {
+
+	selected := make([]*T, 0, gt.selection.size())
+	for _, item := range gt.loadedRowsLocked() {
+		if gt.selection.Contains(item) {
+			selected = append(selected, item)
 		}
 	}
 
-	// refresh newly selected row
-	for col := 0; col < columnCount; col++ {
-		gt.table.RefreshItem(widget.TableCellID{Row: rowNum, Col: col})
+	if gt.pager != nil && len(selected) < gt.selection.size() {
+		listed := newItemSet(gt.selection.keyFunc)
+		for _, item := range selected {
+			listed.Add(item)
+		}
+		for _, item := range gt.selection.members() {
+			if !listed.Contains(item) {
+				selected = append(selected, item)
+			}
+		}
 	}
+	return selected
+}
+
+// SetSelectedItems replaces the current selection, items not in the table are ignored
+func (gt *GenericTable[T]) SetSelectedItems(items []*T) {
+
+	gt.mu.Lock()
+	gt.selection.RemoveAll()
+	for _, item := range gt.limitSelectionLocked(items) {
+		gt.selection.Add(item)
+	}
+	if gt.pager == nil {
+		gt.selection.retainOnly(gt.data)
+	}
+	gt.mu.Unlock()
+	gt.selectionChanged()
+}
+
+func (gt *GenericTable[T]) IsSelected(item *T) bool {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.selection.Contains(item)
+}
+
+func (gt *GenericTable[T]) ClearSelection() {
+
+	gt.mu.Lock()
+	empty := gt.selection.size() == 0
+	gt.selection.RemoveAll()
+	gt.mu.Unlock()
+
+	if empty {
+		return
+	}
+	gt.unselectCells()
+	gt.selectionChanged()
 }
 
 func (gt *GenericTable[T]) setupHeaders() {
@@ -145,152 +338,513 @@ func (gt *GenericTable[T]) setupHeaders() {
 	gt.table.UpdateHeader = func(id widget.TableCellID, cell fyne.CanvasObject) {
 		header := cell.(*HeaderLabel)
 
-		if id.Row == -1 {
-			labelTxt := gt.columns[id.Col].field.Label
-			if gt.sortCol == id.Col {
-				if gt.sortAsc {
-					labelTxt += " ↑"
-				} else {
-					labelTxt += " ↓"
-				}
-			}
-			header.SetText(labelTxt)
-			header.onTapped = func() {
-				gt.sortOn(id.Col)
+		idx := gt.columnIndex(id.Col)
+		if id.Row != -1 || idx < 0 {
+			return
+		}
+		header.SetText(gt.columns[idx].field.Label + gt.sortIndicator(idx))
+		header.onTapped = func() {
+			gt.sortOn(idx, shiftPressed())
+		}
+		header.onMenu = gt.showColumnMenu
+		header.onDragged = func(event *fyne.DragEvent) { gt.headerDragged(header, id.Col, event) }
+		header.onDragEnd = func() { gt.headerDropped(header, id.Col) }
+		header.onResized = func(width float32) {
+			if gt.table.resizing {
+				gt.columnResized(id.Col, width)
 			}
 		}
 	}
+	gt.table.onResized = gt.layoutChanged
+}
+
+// refreshRowsLocked rebuilds the visible rows from the fetched items, filtering and sorting unless the source does
+func (gt *GenericTable[T]) refreshRowsLocked() {
+
+	rows := make([]*T, 0, len(gt.data))
+	for _, item := range gt.data {
+		if gt.isVisibleLocked(item) {
+			rows = append(rows, item)
+		}
+	}
+	gt.sortRowsLocked(rows)
+	gt.rows = rows
+}
+
+// reload fetches the items from the source again, keeping the previous ones if that fails.
+// When paged the cached pages are dropped and reloaded as they are shown.
+func (gt *GenericTable[T]) reload() {
+	gt.reloadFrom(0)
+}
+
+// reloadFrom is reload for a change starting at the row, when paged the pages before it are kept
+func (gt *GenericTable[T]) reloadFrom(row int) {
+
+	gt.mu.Lock()
+	source, pager := gt.source, gt.pager
+	gt.mu.Unlock()
+
+	if pager != nil {
+		pager.invalidateFrom(row)
+		gt.redraw()
+		return
+	}
+
+	data, err := fetchAll(source)
+	if err != nil {
+		gt.reportError(err)
+		return
+	}
+
+	gt.mu.Lock()
+	gt.data = data
+	gt.selection.retainOnly(data)
+	gt.refreshRowsLocked()
+	gt.mu.Unlock()
+	gt.redraw()
+}
+
+func (gt *GenericTable[T]) isVisibleLocked(item *T) bool {
+	if _, delegated := gt.source.(FilteringSource[T]); delegated {
+		return true
+	}
+	return gt.filter.Match == nil || gt.filter.Match(*item)
 }
 
-func (gt *GenericTable[T]) sortOn(columnIdx int) {
+// SetFilter restricts the visible rows to the items accepted by the filter, nil shows everything
+func (gt *GenericTable[T]) SetFilter(filter func(T) bool) {
+	gt.applyFilter(SourceFilter[T]{Match: filter})
+}
 
-	gt.sortCol = columnIdx
-	asc := gt.sortAsc
+func (gt *GenericTable[T]) applyFilter(filter SourceFilter[T]) {
 
-	lt := gt.columns[columnIdx].field.LessThan()
+	gt.mu.Lock()
+	fs, delegated := gt.source.(FilteringSource[T])
+	if gt.pager != nil && !delegated {
+		gt.mu.Unlock()
+		gt.reportError(fmt.Errorf("The data source can't filter its rows"))
+		return
+	}
+	gt.filter = filter
+	if !delegated {
+		gt.refreshRowsLocked()
+	}
+	gt.mu.Unlock()
 
-	sort.Slice(gt.data, func(i, j int) bool {
-		if asc {
-			return lt((*gt.data[i]), (*gt.data[j]))
+	gt.unselectCells()
+	if delegated {
+		if err := fs.SetFilter(filter); err != nil {
+			gt.reportError(err)
 		}
-		return lt((*gt.data[j]), (*gt.data[i]))
-	})
+		gt.reload()
+	}
+	gt.selectionChanged()
+}
 
-	gt.sortAsc = !asc
-	gt.table.Refresh()
+// VisibleData returns the items that pass the filter in display order, only those cached when paged
+func (gt *GenericTable[T]) VisibleData() []*T {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return append([]*T(nil), gt.loadedRowsLocked()...)
 }
 
 func (gt *GenericTable[T]) setupHandlers() {
 
-	gt.table.OnSelected = func(id widget.TableCellID) {
-		gt.selectedRows.Add(id.Row)
+	gt.table.OnSelected = gt.cellSelected
+
+	gt.table.onTypedKey = gt.typedKey
+}
+
+func (gt *GenericTable[T]) cellDoubleTapped(cell *TableCell) {
+	gt.cellTapped(cell)
+	gt.editCell(cell.id)
+}
+
+// SetValidator sets the validator for whole items, applied to edits before they are committed
+func (gt *GenericTable[T]) SetValidator(validator meta.Validator[T]) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.validator = validator
+}
+
+// Validate runs the field validators and then the item validator
+func (gt *GenericTable[T]) Validate(item T) error {
+
+	for _, col := range gt.columns {
+		if v := col.field.Validator; v != nil {
+			if err := v(item); err != nil {
+				return err
+			}
+		}
 	}
 
-	gt.table.OnUnselected = func(id widget.TableCellID) {
-		gt.selectedRows.Remove(id.Row)
+	if validator := gt.itemValidator(); validator != nil {
+		return validator.Validate(item)
 	}
+	return nil
 }
 
-func (gt *GenericTable[T]) SetData(data []*T) {
-	gt.data = data
-	gt.selectedRows.RemoveAll()
-	gt.table.Refresh()
+// itemValidator returns the validator for whole items, nil if there is none
+func (gt *GenericTable[T]) itemValidator() meta.Validator[T] {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.validator
 }
 
-func (gt *GenericTable[T]) GetData() []*T {
-	return gt.data
+func (gt *GenericTable[T]) indexOf(item *T) int {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return slices.Index(gt.data, item)
 }
 
-func (gt *GenericTable[T]) AddItem(item *T) {
-	gt.data = append(gt.data, item)
-	gt.table.Refresh()
+// SetDataSource backs the table with the source, handing it the current sort and filter if it handles them
+func (gt *GenericTable[T]) SetDataSource(source DataSource[T]) {
+	gt.setSource(source, nil)
 }
 
-// Replaces the item at the index with the new one
-func (gt *GenericTable[T]) ItemEdited(idx int, item *T) {
-	gt.data[idx] = item
-	gt.table.Refresh()
+func (gt *GenericTable[T]) setSource(source DataSource[T], pager *pager[T]) {
+
+	gt.clearHistory()
+
+	gt.mu.Lock()
+	stop := gt.stopListening
+	gt.source = source
+	gt.pager = pager
+	gt.data, gt.rows = nil, nil
+	gt.changes = newChangeTracker[T]()
+	if pager != nil {
+		gt.selection.RemoveAll()
+	}
+	filter, sortFields := gt.filter, gt.sortFieldsLocked()
+	gt.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	stopListening := source.AddChangeListener(gt.sourceChanged)
+	gt.mu.Lock()
+	gt.stopListening = stopListening
+	gt.mu.Unlock()
+
+	if fs, ok := source.(FilteringSource[T]); ok {
+		if err := fs.SetFilter(filter); err != nil {
+			gt.reportError(err)
+		}
+	}
+	if ss, ok := source.(SortingSource[T]); ok {
+		if err := ss.SetSort(sortFields); err != nil {
+			gt.reportError(err)
+		}
+	}
+
+	gt.unselectCells()
+	gt.reload()
+	gt.selectionChanged()
 }
 
-func (gt *GenericTable[T]) SelectedItemsByIdx() map[int]*T {
+func (gt *GenericTable[T]) DataSource() DataSource[T] {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.source
+}
 
-	if gt.selectedRows.size() < 0 {
-		return map[int]*T{}
+// sourceChanged keeps the selection on the changed items and shows the new contents.
+// During a batch update the contents are only fetched once it ends.
+func (gt *GenericTable[T]) sourceChanged(change DataChange[T]) {
+
+	gt.mu.Lock()
+
+	gt.changes.apply(change)
+	switch change.Kind {
+	case ItemsUpdated:
+		for i, old := range change.Previous {
+			if i < len(change.Items) && gt.selection.Contains(old) { // carry the selection over to the replacement
+				gt.selection.Remove(old)
+				gt.selection.Add(change.Items[i])
+			}
+		}
+	case ItemsDeleted:
+		for _, item := range change.Items {
+			gt.selection.Remove(item)
+		}
 	}
+	reselect := change.Kind == ItemsDeleted || change.Kind == ItemsReloaded
 
-	// Build a map of indices to delete
-	selected := make(map[int]*T)
-	for key := range gt.selectedRows {
-		selected[key] = gt.data[key]
+	if gt.batchDepth > 0 {
+		if !gt.pending.reload || change.Offset < gt.pending.offset {
+			gt.pending.offset = change.Offset
+		}
+		gt.pending.reload = true
+		gt.pending.reselect = gt.pending.reselect || reselect
+		gt.mu.Unlock()
+		return
+	}
+
+	if change.Kind == ItemsUpdated {
+		if rows, ok := gt.patchUpdatedLocked(change); ok {
+			gt.mu.Unlock()
+			gt.refreshRows(rows)
+			return
+		}
+	}
+	gt.mu.Unlock()
+
+	gt.reloadFrom(change.Offset)
+	if reselect {
+		gt.unselectCells()
+		gt.selectionChanged()
 	}
-	return selected
 }
 
-// DeleteSelected removes all selected items from the table
-func (gt *GenericTable[T]) DeleteSelected() int {
-	if gt.selectedRows.size() == 0 {
-		return 0
+// patchUpdatedLocked swaps the updated items in without fetching, returning the rows to refresh, or nil for all
+// of them if they may have moved. Returns false if the items have to be fetched again.
+func (gt *GenericTable[T]) patchUpdatedLocked(change DataChange[T]) ([]int, bool) {
+
+	_, sorts := gt.source.(SortingSource[T])
+	_, filters := gt.source.(FilteringSource[T])
+	if gt.pager == nil && (sorts || filters) {
+		return nil, false // only the source knows where they belong now
 	}
 
-	// Build a map of indices to delete
-	toDelete := make(map[int]bool)
-	for key := range gt.selectedRows {
-		toDelete[key] = true
+	var rows []int
+	for i, old := range change.Previous {
+		if i >= len(change.Items) {
+			break
+		}
+		item := change.Items[i]
+
+		if gt.pager != nil {
+			if !gt.pager.replace(old, item) {
+				return nil, false
+			}
+			rows = append(rows, gt.pager.rowOf(item))
+			continue
+		}
+
+		idx := slices.Index(gt.data, old)
+		if idx < 0 {
+			return nil, false
+		}
+		gt.data[idx] = item
+		row := slices.Index(gt.rows, old)
+		if row >= 0 {
+			gt.rows[row] = item
+		}
+		rows = append(rows, row)
 	}
 
-	// Create new slice without deleted items
-	newData := make([]*T, 0, len(gt.data)-len(toDelete))
-	for i, item := range gt.data {
-		if !toDelete[i] {
-			newData = append(newData, item)
+	if gt.pager == nil && (len(gt.sortKeys) > 0 || gt.filter.Match != nil) {
+		gt.refreshRowsLocked()
+		return nil, true
+	}
+	return rows, true
+}
+
+// refreshRows redraws the rows, or the whole table if none are given
+func (gt *GenericTable[T]) refreshRows(rows []int) {
+
+	if rows == nil {
+		gt.redraw()
+		return
+	}
+	fyne.Do(func() {
+		for _, row := range rows {
+			if row < 0 {
+				continue
+			}
+			for col := range gt.shownCount() {
+				gt.table.RefreshItem(widget.TableCellID{Row: row, Col: col})
+			}
 		}
+	})
+}
+
+// SetErrorHandler sets where failures of the source are reported when there is no caller to return them to,
+// by default they are logged. The handler runs on the UI goroutine.
+func (gt *GenericTable[T]) SetErrorHandler(handler func(error)) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.onError = handler
+}
+
+func (gt *GenericTable[T]) reportError(err error) {
+
+	gt.mu.Lock()
+	handler := gt.onError
+	gt.mu.Unlock()
+
+	if handler != nil {
+		fyne.Do(func() { handler(err) })
+		return
 	}
+	fyne.LogError("Data source failed", err)
+}
 
-	deleted := len(gt.data) - len(newData)
-	gt.data = newData
-	gt.selectedRows.RemoveAll()
-	gt.table.Refresh()
+// SetData replaces the table contents, keeping any selected items that are still present, and clears the undo history.
+// A table backed by another kind of source is switched to an in-memory one.
+func (gt *GenericTable[T]) SetData(data []*T) {
 
-	return deleted
+	if ss, ok := gt.DataSource().(*SliceSource[T]); ok {
+		gt.clearHistory()
+		ss.SetItems(data)
+		return
+	}
+	gt.SetDataSource(NewSliceSource(data))
 }
 
-func (gt *GenericTable[T]) GetSelectedCount() int {
-	return gt.selectedRows.size()
+// GetData returns the items fetched from the source, only the matching ones if the source does the filtering
+func (gt *GenericTable[T]) GetData() []*T {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return append([]*T(nil), gt.data...)
 }
 
-func (gt *GenericTable[T]) CreateRenderer() fyne.WidgetRenderer {
-	return widget.NewSimpleRenderer(gt.table)
+// AddItem adds the item to the source, reporting a failure through the error handler
+func (gt *GenericTable[T]) AddItem(item *T) {
+	if err := gt.InsertItem(item); err != nil {
+		gt.reportError(err)
+	}
 }
 
-func (gt *GenericTable[T]) SelectAll() {
+// InsertItem adds the item to the source
+func (gt *GenericTable[T]) InsertItem(item *T) error {
 
-	for r := 0; r < len(gt.data); r++ {
-		for c := 0; c < len(gt.columns); c++ {
-			gt.table.Select(widget.TableCellID{Row: r, Col: c})
-		}
+	source := gt.DataSource()
+	if err := source.Insert(item); err != nil {
+		return err
+	}
+	gt.record(&insertCommand[T]{gt: gt, source: source, items: []*T{item}})
+	return nil
+}
+
+// ReplaceItem replaces the item with the new one in the source
+func (gt *GenericTable[T]) ReplaceItem(old, item *T) error {
+
+	source := gt.DataSource()
+	if err := source.Update(old, item); err != nil {
+		return err
+	}
+	gt.record(&replaceCommand[T]{source: source, old: old, item: item})
+	return nil
+}
+
+// Replaces the item at the index with the new one, reporting a failure through the error handler
+func (gt *GenericTable[T]) ItemEdited(idx int, item *T) {
+	if err := gt.ReplaceItemAt(idx, item); err != nil {
+		gt.reportError(err)
 	}
-	gt.table.Refresh()
 }
 
-// ==================== copy-selection-to-clipboard =======================
+// ReplaceItemAt replaces the item at the index in the full dataset with the new one
+func (gt *GenericTable[T]) ReplaceItemAt(idx int, item *T) error {
 
-func (gt *GenericTable[T]) SelectionAsString(columnSeparator string, lineSeparator string) string {
+	gt.mu.Lock()
+	if idx < 0 || idx >= len(gt.data) {
+		gt.mu.Unlock()
+		return fmt.Errorf("No item at index %d", idx)
+	}
+	old := gt.data[idx]
+	gt.mu.Unlock()
 
-	var sb strings.Builder
+	return gt.ReplaceItem(old, item)
+}
 
-	for _, item := range gt.SelectedItemsByIdx() {
-		gt.asLineOn(&sb, (*item), columnSeparator)
-		sb.WriteString(lineSeparator)
+// SelectedItemsByIdx returns the visible selected items keyed by their index in the full dataset
+func (gt *GenericTable[T]) SelectedItemsByIdx() map[int]*T {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	selected := make(map[int]*T)
+	for idx, item := range gt.data {
+		if gt.selection.Contains(item) && gt.isVisibleLocked(item) {
+			selected[idx] = item
+		}
+	}
+	return selected
+}
+
+// DeleteSelected removes all visible selected items from the table, reporting a failure through the error handler.
+// Returns the number deleted.
+func (gt *GenericTable[T]) DeleteSelected() int {
+	deleted, err := gt.DeleteSelectedItems()
+	if err != nil {
+		gt.reportError(err)
 	}
-	return sb.String()
+	return deleted
 }
 
-func (gt *GenericTable[T]) asLineOn(sb *strings.Builder, item T, separator string) {
+// DeleteSelectedItems removes all visible selected items from the source, returning the number deleted
+func (gt *GenericTable[T]) DeleteSelectedItems() (int, error) {
 
-	sb.WriteString(gt.columns[0].field.Accessor(item))
+	selected := gt.SelectedItems()
+	if len(selected) == 0 {
+		return 0, nil
+	}
+	source := gt.DataSource()
+	command := newDeleteCommand(gt, source, selected)
+	if err := source.Delete(selected); err != nil {
+		return 0, err
+	}
+	gt.record(command)
+	return len(selected), nil
+}
 
-	for i := 1; i < len(gt.columns); i++ {
-		sb.WriteString(separator)
-		sb.WriteString(gt.columns[i].field.Accessor(item))
+// ItemsChanged redraws the table after items were modified in place, re-applying a local sort and filter
+func (gt *GenericTable[T]) ItemsChanged() {
+
+	gt.mu.Lock()
+	if gt.pager == nil {
+		gt.refreshRowsLocked()
 	}
+	gt.mu.Unlock()
+	gt.redraw()
+}
+
+type pendingChanges struct {
+	reload   bool
+	offset   int // the first row changed
+	reselect bool
+}
+
+// BatchUpdate runs fn, typically a worker adding, replacing or deleting many items, and refreshes the table
+// once when it returns rather than after every change. Batches may nest, the outermost one refreshes.
+func (gt *GenericTable[T]) BatchUpdate(fn func() error) error {
+
+	gt.mu.Lock()
+	gt.batchDepth++
+	gt.mu.Unlock()
+
+	defer func() {
+		gt.mu.Lock()
+		gt.batchDepth--
+		var pending pendingChanges
+		if gt.batchDepth == 0 {
+			pending, gt.pending = gt.pending, pendingChanges{}
+		}
+		gt.mu.Unlock()
+
+		if pending.reload {
+			gt.reloadFrom(pending.offset)
+		}
+		if pending.reselect {
+			gt.unselectCells()
+			gt.selectionChanged()
+		}
+	}()
+
+	return fn()
+}
+
+func (gt *GenericTable[T]) GetSelectedCount() int {
+	return len(gt.SelectedItems())
+}
+
+func (gt *GenericTable[T]) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(gt.table)
+}
+
+// SelectAll selects all visible rows, only those cached when paged
+func (gt *GenericTable[T]) SelectAll() {
+	gt.SetSelectedItems(gt.VisibleData())
 }
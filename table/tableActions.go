@@ -0,0 +1,267 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// AsyncAction runs off the UI goroutine. It reports each item it is done with through the progress,
+// including why it failed, and should stop once the context is cancelled.
+type AsyncAction[T any] func(ctx context.Context, items []*T, progress *Progress[T]) error
+
+// ItemError is an item an action failed on
+type ItemError[T any] struct {
+	Item *T
+	Err  error
+}
+
+// Progress tracks an async action, it is safe for concurrent use
+type Progress[T any] struct {
+	mu       sync.Mutex
+	total    int
+	done     int
+	status   string
+	failed   []ItemError[T]
+	onChange func(done, total int, status string)
+}
+
+func newProgress[T any](total int, onChange func(done, total int, status string)) *Progress[T] {
+	return &Progress[T]{total: total, onChange: onChange}
+}
+
+// SetTotal changes the amount of work expected, the number of items unless set
+func (p *Progress[T]) SetTotal(total int) {
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+	p.changed()
+}
+
+// SetStatus shows a line of text with the progress
+func (p *Progress[T]) SetStatus(status string) {
+	p.mu.Lock()
+	p.status = status
+	p.mu.Unlock()
+	p.changed()
+}
+
+// Done counts the item as handled, as failed if err isn't nil
+func (p *Progress[T]) Done(item *T, err error) {
+	p.mu.Lock()
+	p.done++
+	if err != nil {
+		p.failed = append(p.failed, ItemError[T]{Item: item, Err: err})
+	}
+	p.mu.Unlock()
+	p.changed()
+}
+
+// Failed returns the items reported as failed so far
+func (p *Progress[T]) Failed() []ItemError[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.failed)
+}
+
+func (p *Progress[T]) changed() {
+
+	p.mu.Lock()
+	done, total, status := p.done, p.total, p.status
+	p.mu.Unlock()
+
+	if p.onChange != nil {
+		p.onChange(done, total, status)
+	}
+}
+
+// ==================== running actions =======================
+
+// actionRun is an async action in progress
+type actionRun[T any] struct {
+	action   ItemAction[T]
+	idx      int // of the action's button, -1 for those only in menus
+	items    []*T
+	before   []T // copies of the items as they were, to track what the action changed
+	cancel   context.CancelFunc
+	progress *Progress[T]
+	view     *progressView
+	dialog   dialog.Dialog // nil when shown in the status bar
+}
+
+// progressView shows the progress of a run with a button to cancel it
+type progressView struct {
+	bar     *widget.ProgressBar
+	status  *widget.Label
+	cancel  *widget.Button
+	content *fyne.Container
+}
+
+func newProgressView(label string, cancel func()) *progressView {
+
+	pv := &progressView{
+		bar:    widget.NewProgressBar(),
+		status: widget.NewLabel(label),
+	}
+	pv.cancel = widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
+		pv.cancel.Disable()
+		pv.status.SetText("Cancelling…")
+		cancel()
+	})
+	pv.content = container.NewBorder(nil, nil, nil, pv.cancel, container.NewVBox(pv.status, pv.bar))
+	return pv
+}
+
+func (pv *progressView) update(done, total int, status string) {
+
+	if total > 0 {
+		pv.bar.SetValue(float64(done) / float64(total))
+	}
+	if status != "" && !pv.cancel.Disabled() {
+		pv.status.SetText(status)
+	}
+}
+
+// runAsync starts the action on the selected items, showing its progress until it completes
+func (tc *TableContainer[T]) runAsync(action ItemAction[T], actionIdx int) {
+
+	items := tc.table.SelectedItems()
+	if len(items) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &actionRun[T]{action: action, idx: actionIdx, items: items, before: snapshotOf(items), cancel: cancel}
+	run.view = newProgressView(fmt.Sprintf("%s %d item(s)…", tc.actionName(action), len(items)), cancel)
+	run.progress = newProgress[T](len(items), func(done, total int, status string) {
+		fyne.Do(func() { run.view.update(done, total, status) })
+	})
+
+	if action.StatusBar {
+		tc.status.Add(run.view.content)
+		tc.status.Show()
+	} else {
+		run.dialog = dialog.NewCustomWithoutButtons(tc.actionName(action), run.view.content, tc.window)
+		run.dialog.Resize(fyne.NewSize(400, 0))
+		run.dialog.Show()
+	}
+
+	tc.running = append(tc.running, run)
+	tc.updateEditButtons()
+
+	go func() {
+		var err error
+		defer func() { // a panicking action still has to finish, or the table stays busy
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s failed: %v", tc.actionName(action), r)
+			}
+			cancelled := ctx.Err() != nil
+			cancel()
+			fyne.Do(func() { tc.asyncDone(run, err, cancelled) })
+		}()
+		err = action.Async(ctx, items, run.progress)
+	}()
+}
+
+// asyncDone removes the progress of the run and reports what failed
+func (tc *TableContainer[T]) asyncDone(run *actionRun[T], err error, cancelled bool) {
+
+	if run.dialog != nil {
+		run.dialog.Hide()
+	} else {
+		tc.status.Remove(run.view.content)
+		if len(tc.status.Objects) == 0 {
+			tc.status.Hide()
+		}
+	}
+
+	tc.running = slices.DeleteFunc(tc.running, func(other *actionRun[T]) bool { return other == run })
+	tc.table.changedInPlace(run.items, run.before)
+	tc.table.ItemsChanged()
+	tc.updateEditButtons()
+
+	if cancelled && errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	failed := run.progress.Failed()
+	if err != nil || len(failed) > 0 {
+		tc.showActionErrors(run.action, err, failed, cancelled)
+	}
+}
+
+// CancelActions stops the async actions still running
+func (tc *TableContainer[T]) CancelActions() {
+	for _, run := range tc.running {
+		run.cancel()
+	}
+}
+
+// IsBusy tells whether an async action that conflicts with changing the items is running
+func (tc *TableContainer[T]) IsBusy() bool {
+	return slices.ContainsFunc(tc.running, func(run *actionRun[T]) bool {
+		return !run.action.Concurrent
+	})
+}
+
+func (tc *TableContainer[T]) isRunning(actionIdx int) bool {
+	return actionIdx >= 0 && slices.ContainsFunc(tc.running, func(run *actionRun[T]) bool { return run.idx == actionIdx })
+}
+
+func (tc *TableContainer[T]) actionName(action ItemAction[T]) string {
+	if action.Label == "" {
+		return "Action"
+	}
+	return action.Label
+}
+
+// showActionErrors lists the items the action failed on
+func (tc *TableContainer[T]) showActionErrors(action ItemAction[T], err error, failed []ItemError[T], cancelled bool) {
+
+	summary := fmt.Sprintf("%d item(s) failed", len(failed))
+	if cancelled {
+		summary += ", the action was cancelled"
+	}
+	if err != nil {
+		summary = err.Error() + "\n" + summary
+	}
+
+	list := widget.NewList(
+		func() int { return len(failed) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(widget.NewIcon(theme.ErrorIcon()), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*fyne.Container).Objects[1].(*widget.Label)
+			label.SetText(tc.table.itemName(failed[id].Item) + ": " + failed[id].Err.Error())
+		},
+	)
+
+	content := container.NewBorder(widget.NewLabel(summary), nil, nil, nil, list)
+	summaryDialog := dialog.NewCustom(tc.actionName(action), "Close", content, tc.window)
+	if len(failed) > 0 {
+		summaryDialog.Resize(fyne.NewSize(500, 300))
+	}
+	summaryDialog.Show()
+}
+
+// itemName describes the item by the value of its first text column
+func (gt *GenericTable[T]) itemName(item *T) string {
+
+	if item == nil {
+		return ""
+	}
+	for _, col := range gt.columns {
+		if !col.IsIcon() {
+			return col.StringValueFor(*item)
+		}
+	}
+	return fmt.Sprintf("%v", *item)
+}
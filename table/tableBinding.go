@@ -0,0 +1,477 @@
+package table
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// BoundListSource presents a bound list holding *T or T values as a data source. Appends, removals and
+// changes made through the binding show in the table, and edits made in the table are set back into the
+// list so its other listeners see them too. The list is only listened to while the source has change
+// listeners, so a table that moves on to another source lets go of it. It is safe for concurrent use.
+type BoundListSource[T any] struct {
+	mu            sync.Mutex
+	list          binding.UntypedList
+	byValue       bool                 // the list holds T rather than *T, values are set into it that way
+	items         []*T                 // per list index, nil where a value isn't a T
+	listListener  binding.DataListener // nil while nobody listens to the source
+	itemListeners []itemListener
+	listeners     map[int]func(DataChange[T])
+	nextID        int
+
+	pendingMu sync.Mutex
+	pending   map[int]binding.Untyped // the items set since the last flush, by list index
+}
+
+type itemListener struct {
+	item     binding.DataItem
+	listener binding.DataListener
+}
+
+// BindingMode is what a bound list holds
+type BindingMode int
+
+const (
+	BindPointers BindingMode = iota // *T values
+	BindValues                      // T values, copied in and out of the list
+)
+
+// NewGenericTableFromBinding creates a table showing the bound list, see BoundListSource
+func NewGenericTableFromBinding[T any](columns []Column[T], newItemFunc func() T, list binding.UntypedList, mode BindingMode) *GenericTable[T] {
+
+	gt := NewGenericTable(columns, newItemFunc)
+	gt.SetDataSource(NewBoundListSource[T](list, mode))
+	return gt
+}
+
+// NewBoundListSource adapts the list, which holds values of T or pointers to them as the mode says
+func NewBoundListSource[T any](list binding.UntypedList, mode BindingMode) *BoundListSource[T] {
+
+	bs := &BoundListSource[T]{list: list, byValue: mode == BindValues, listeners: map[int]func(DataChange[T]){}}
+
+	values, _ := list.Get()
+	bs.sync(values)
+	return bs
+}
+
+// listDiff is what a change of the list did to the items
+type listDiff[T any] struct {
+	inserted []*T
+	deleted  []*T
+	updated  []*T
+	previous []*T // the items the updated ones replace
+	offset   int  // the first index that changed
+}
+
+// sync brings the items, and the item listeners while listening, in line with the values, working out which
+// items were inserted, deleted or replaced. Values that moved keep their items.
+func (bs *BoundListSource[T]) sync(values []any) listDiff[T] {
+
+	bs.mu.Lock()
+
+	var diff listDiff[T]
+	dropped := func(items []*T) {
+		for _, item := range items {
+			if item != nil {
+				diff.deleted = append(diff.deleted, item)
+			}
+		}
+	}
+
+	old := bs.items
+	items := make([]*T, len(values))
+	j := 0 // the first old item not accounted for
+	for i, value := range values {
+		if j < len(old) && bs.matches(value, old[j]) {
+			items[i] = old[j]
+			j++
+			continue
+		}
+		if k := bs.indexOf(value, old[min(j+1, len(old)):]); k >= 0 { // the items in between went
+			k += j + 1
+			dropped(old[j:k])
+			items[i] = old[k]
+			j = k + 1
+			continue
+		}
+
+		item := bs.itemFor(value)
+		items[i] = item
+		replaced := j < len(old) && !slices.ContainsFunc(values[i+1:], func(v any) bool { return bs.matches(v, old[j]) })
+		switch {
+		case replaced && item != nil && old[j] != nil:
+			diff.updated = append(diff.updated, item)
+			diff.previous = append(diff.previous, old[j])
+		case replaced:
+			dropped(old[j : j+1])
+			fallthrough
+		case item != nil:
+			if item != nil {
+				diff.inserted = append(diff.inserted, item)
+			}
+		}
+		if replaced {
+			j++
+		}
+	}
+	dropped(old[min(j, len(old)):])
+	for diff.offset < min(len(old), len(items)) && old[diff.offset] == items[diff.offset] {
+		diff.offset++
+	}
+	bs.items = items
+
+	var removed []itemListener
+	if bs.listListener == nil {
+		bs.mu.Unlock()
+		return diff
+	}
+	if len(bs.itemListeners) > len(values) {
+		removed = bs.itemListeners[len(values):]
+		bs.itemListeners = bs.itemListeners[:len(values)]
+	}
+	first := len(bs.itemListeners)
+	for i := first; i < len(values); i++ {
+		item, err := bs.list.GetItem(i)
+		if err != nil {
+			break
+		}
+		idx, untyped := i, item.(binding.Untyped)
+		listener := binding.NewDataListener(func() { bs.itemChanged(idx, untyped) })
+		bs.itemListeners = append(bs.itemListeners, itemListener{item: item, listener: listener})
+	}
+	added := bs.itemListeners[first:]
+	bs.mu.Unlock()
+
+	// the binding may call the listeners straight away, so they are attached outside the lock
+	for _, il := range removed {
+		il.item.RemoveListener(il.listener)
+	}
+	for _, il := range added {
+		il.item.AddListener(il.listener)
+	}
+	return diff
+}
+
+// matches tells whether the list value is the item, or equal to it for lists of values
+func (bs *BoundListSource[T]) matches(value any, item *T) bool {
+
+	if item == nil {
+		return false
+	}
+	switch v := value.(type) {
+	case *T:
+		return v == item
+	case T:
+		return reflect.DeepEqual(*item, v)
+	}
+	return false
+}
+
+func (bs *BoundListSource[T]) indexOf(value any, items []*T) int {
+	return slices.IndexFunc(items, func(item *T) bool { return bs.matches(value, item) })
+}
+
+// itemFor returns a new item for a list value, nil if the value isn't a T
+func (bs *BoundListSource[T]) itemFor(value any) *T {
+
+	switch v := value.(type) {
+	case *T:
+		return v
+	case T:
+		return &v
+	}
+	fyne.LogError("Bound list value ignored", fmt.Errorf("%T is not a %s", value, reflect.TypeFor[T]()))
+	return nil
+}
+
+// pointerTo returns the item for a list value, keeping the current one if the value is unchanged
+func (bs *BoundListSource[T]) pointerTo(value any, current *T) (*T, bool) {
+
+	if bs.matches(value, current) {
+		return current, false
+	}
+	item := bs.itemFor(value)
+	return item, item != current
+}
+
+// listChanged runs when the length of the list changes
+func (bs *BoundListSource[T]) listChanged() {
+
+	values, err := bs.list.Get()
+	if err != nil {
+		return
+	}
+	bs.notifyDiff(bs.sync(values))
+}
+
+// notifyDiff tells the listeners about the deleted, updated and inserted items in that order
+func (bs *BoundListSource[T]) notifyDiff(diff listDiff[T]) {
+
+	if len(diff.deleted) > 0 {
+		bs.notify(DataChange[T]{Kind: ItemsDeleted, Items: diff.deleted, Offset: diff.offset})
+	}
+	if len(diff.updated) > 0 {
+		bs.notify(DataChange[T]{Kind: ItemsUpdated, Items: diff.updated, Previous: diff.previous, Offset: diff.offset})
+	}
+	if len(diff.inserted) > 0 {
+		bs.notify(DataChange[T]{Kind: ItemsInserted, Items: diff.inserted, Offset: diff.offset})
+	}
+}
+
+// itemChanged runs when a value in the list is set, possibly with the list still locked. The items set are
+// collected and looked at together by one flush on the UI goroutine, handed over from another goroutine as
+// a driver may run fyne.Do straight away.
+func (bs *BoundListSource[T]) itemChanged(idx int, untyped binding.Untyped) {
+
+	bs.pendingMu.Lock()
+	defer bs.pendingMu.Unlock()
+
+	if bs.pending == nil {
+		bs.pending = map[int]binding.Untyped{}
+		go fyne.Do(bs.flushItems)
+	}
+	bs.pending[idx] = untyped
+}
+
+// flushItems reports the items set since the last flush. They are left to listChanged if the length changed
+// too, which tells shifted items from new ones.
+func (bs *BoundListSource[T]) flushItems() {
+
+	bs.pendingMu.Lock()
+	pending := bs.pending
+	bs.pending = nil
+	bs.pendingMu.Unlock()
+
+	indices := slices.Sorted(maps.Keys(pending))
+	values := make([]any, len(indices))
+	read := make([]bool, len(indices))
+	for i, idx := range indices {
+		value, err := pending[idx].Get()
+		values[i], read[i] = value, err == nil
+	}
+
+	var diff listDiff[T]
+	diff.offset = -1
+	bs.mu.Lock()
+	if bs.list.Length() != len(bs.items) {
+		bs.mu.Unlock()
+		return
+	}
+	for i, idx := range indices {
+		if idx >= len(bs.items) || !read[i] {
+			continue
+		}
+		old := bs.items[idx]
+		item, changed := bs.pointerTo(values[i], old)
+		if !changed {
+			continue
+		}
+		bs.items[idx] = item
+		if diff.offset < 0 {
+			diff.offset = idx
+		}
+		switch {
+		case item == nil:
+			diff.deleted = append(diff.deleted, old)
+		case old == nil:
+			diff.inserted = append(diff.inserted, item)
+		default:
+			diff.updated = append(diff.updated, item)
+			diff.previous = append(diff.previous, old)
+		}
+	}
+	bs.mu.Unlock()
+
+	bs.notifyDiff(diff)
+}
+
+func (bs *BoundListSource[T]) valueOf(item *T) any {
+	if bs.byValue {
+		return *item
+	}
+	return item
+}
+
+func (bs *BoundListSource[T]) Count() (int, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return len(bs.presentLocked()), nil
+}
+
+func (bs *BoundListSource[T]) Fetch(offset, limit int) ([]*T, error) {
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	items := bs.presentLocked()
+	offset = max(0, min(offset, len(items)))
+	end := min(offset+max(limit, 0), len(items))
+	return items[offset:end], nil
+}
+
+// presentLocked returns the items, leaving out the values that aren't a T
+func (bs *BoundListSource[T]) presentLocked() []*T {
+
+	if !slices.Contains(bs.items, nil) {
+		return append([]*T(nil), bs.items...)
+	}
+	items := make([]*T, 0, len(bs.items))
+	for _, item := range bs.items {
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// The changes below are applied to the items straight away and then set into the list.
+// When the binding reports them back they match the items, so they aren't reported twice.
+
+func (bs *BoundListSource[T]) Insert(item *T) error {
+
+	bs.mu.Lock()
+	offset := len(bs.items)
+	bs.items = append(bs.items, item)
+	bs.mu.Unlock()
+
+	bs.notify(DataChange[T]{Kind: ItemsInserted, Items: []*T{item}, Offset: offset})
+	return bs.list.Append(bs.valueOf(item))
+}
+
+func (bs *BoundListSource[T]) IndexOf(item *T) int {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return slices.Index(bs.items, item)
+}
+
+// InsertAt puts the item before the one at the index, at the end if the index is past it
+func (bs *BoundListSource[T]) InsertAt(index int, item *T) error {
+
+	bs.mu.Lock()
+	values, err := bs.list.Get()
+	if err != nil {
+		bs.mu.Unlock()
+		return err
+	}
+	offset := max(0, min(index, len(bs.items), len(values)))
+	bs.items = slices.Insert(bs.items, offset, item)
+	values = slices.Insert(slices.Clone(values), offset, bs.valueOf(item))
+	bs.mu.Unlock()
+
+	bs.notify(DataChange[T]{Kind: ItemsInserted, Items: []*T{item}, Offset: offset})
+	return bs.list.Set(values)
+}
+
+func (bs *BoundListSource[T]) Update(old, item *T) error {
+
+	bs.mu.Lock()
+	idx := slices.Index(bs.items, old)
+	if idx >= 0 {
+		bs.items[idx] = item
+	}
+	bs.mu.Unlock()
+
+	if idx < 0 {
+		return fmt.Errorf("The item to update is no longer present")
+	}
+	bs.notify(DataChange[T]{Kind: ItemsUpdated, Items: []*T{item}, Previous: []*T{old}, Offset: idx})
+	return bs.list.SetValue(idx, bs.valueOf(item))
+}
+
+func (bs *BoundListSource[T]) Delete(items []*T) error {
+
+	doomed := make(map[*T]bool, len(items))
+	for _, item := range items {
+		doomed[item] = true
+	}
+
+	bs.mu.Lock()
+	values, err := bs.list.Get()
+	if err != nil {
+		bs.mu.Unlock()
+		return err
+	}
+	keptItems := make([]*T, 0, len(bs.items))
+	keptValues := make([]any, 0, len(values))
+	var deleted []*T
+	offset := 0
+	for i, item := range bs.items {
+		if item != nil && doomed[item] {
+			if len(deleted) == 0 {
+				offset = i
+			}
+			deleted = append(deleted, item)
+			continue
+		}
+		keptItems = append(keptItems, item)
+		if i < len(values) {
+			keptValues = append(keptValues, values[i])
+		}
+	}
+	if len(deleted) > 0 {
+		bs.items = keptItems
+	}
+	bs.mu.Unlock()
+
+	if len(deleted) == 0 {
+		return nil
+	}
+	bs.notify(DataChange[T]{Kind: ItemsDeleted, Items: deleted, Offset: offset})
+	return bs.list.Set(keptValues)
+}
+
+func (bs *BoundListSource[T]) AddChangeListener(listener func(DataChange[T])) func() {
+
+	bs.mu.Lock()
+	id := bs.nextID
+	bs.nextID++
+	bs.listeners[id] = listener
+	var attach binding.DataListener
+	if bs.listListener == nil {
+		attach = binding.NewDataListener(bs.listChanged)
+		bs.listListener = attach
+	}
+	bs.mu.Unlock()
+
+	// the binding calls the listener once added, which reads the list again
+	if attach != nil {
+		bs.list.AddListener(attach)
+	}
+
+	return func() {
+		bs.mu.Lock()
+		delete(bs.listeners, id)
+		if len(bs.listeners) > 0 || bs.listListener == nil {
+			bs.mu.Unlock()
+			return
+		}
+		listListener, itemListeners := bs.listListener, bs.itemListeners
+		bs.listListener, bs.itemListeners = nil, nil
+		bs.mu.Unlock()
+
+		bs.list.RemoveListener(listListener)
+		for _, il := range itemListeners {
+			il.item.RemoveListener(il.listener)
+		}
+	}
+}
+
+func (bs *BoundListSource[T]) notify(change DataChange[T]) {
+
+	bs.mu.Lock()
+	listeners := make([]func(DataChange[T]), 0, len(bs.listeners))
+	for _, listener := range bs.listeners {
+		listeners = append(listeners, listener)
+	}
+	bs.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
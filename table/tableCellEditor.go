@@ -0,0 +1,220 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// cellEditor is the entry shown over a cell while it is edited in place
+type cellEditor struct {
+	widget.Entry
+	onKey  func(*fyne.KeyEvent) bool // true if the key was consumed
+	errMsg *widget.Label
+	popUp  *widget.PopUp
+	id     widget.TableCellID
+}
+
+func newCellEditor() *cellEditor {
+
+	e := &cellEditor{
+		errMsg: widget.NewLabel(""),
+	}
+	e.errMsg.Importance = widget.DangerImportance
+	e.errMsg.Hide()
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// AcceptsTab keeps Tab from moving the focus so it can move to the next editable cell
+func (e *cellEditor) AcceptsTab() bool {
+	return true
+}
+
+func (e *cellEditor) TypedKey(event *fyne.KeyEvent) {
+	if e.onKey != nil && e.onKey(event) {
+		return
+	}
+	e.Entry.TypedKey(event)
+}
+
+func (e *cellEditor) showError(err error) {
+
+	if err == nil {
+		e.errMsg.Hide()
+	} else {
+		e.errMsg.SetText(err.Error())
+		e.errMsg.Show()
+	}
+	e.SetValidationError(err)
+	e.popUp.Resize(fyne.NewSize(e.popUp.Size().Width, e.popUp.Content.MinSize().Height))
+}
+
+// ==================== in-place editing =======================
+
+var errBusy = fmt.Errorf("Items can't be edited while an action is running")
+
+// isBusy tells whether an action running in the container keeps the items from being edited
+func (gt *GenericTable[T]) isBusy() bool {
+	return gt.busy != nil && gt.busy()
+}
+
+func (gt *GenericTable[T]) isEditable(col int) bool {
+	column := gt.shownColumn(col)
+	return column != nil && !column.IsIcon() && column.field.IsEditable()
+}
+
+// editCell shows the editor over the cell if its column can be edited
+func (gt *GenericTable[T]) editCell(id widget.TableCellID) {
+
+	if gt.rowAt(id.Row) == nil || !gt.isEditable(id.Col) || gt.isBusy() {
+		return
+	}
+
+	gt.table.ScrollTo(id)
+	cell, ok := gt.cells[id]
+	if !ok || cell.id != id {
+		return // not on screen
+	}
+
+	cnvs := fyne.CurrentApp().Driver().CanvasForObject(gt.table)
+	if cnvs == nil {
+		return
+	}
+
+	gt.cancelEdit()
+
+	editor := newCellEditor()
+	editor.id = id
+	editor.SetText(gt.shownColumn(id.Col).StringValueFor(*gt.rowAt(id.Row)))
+	editor.onKey = func(event *fyne.KeyEvent) bool { return gt.editorKey(editor, event) }
+	editor.popUp = widget.NewPopUp(container.NewVBox(editor, editor.errMsg), cnvs)
+
+	gt.editor = editor
+	gt.cursor = id
+
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(cell)
+	editor.popUp.ShowAtPosition(pos)
+	editor.popUp.Resize(fyne.NewSize(cell.Size().Width, editor.popUp.Content.MinSize().Height))
+	cnvs.Focus(editor)
+}
+
+func (gt *GenericTable[T]) editorKey(editor *cellEditor, event *fyne.KeyEvent) bool {
+
+	switch event.Name {
+	case fyne.KeyEscape:
+		gt.cancelEdit()
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if gt.commitEdit(editor) != nil {
+			gt.cancelEdit()
+		}
+	case fyne.KeyTab:
+		next, found := gt.nextEditableCell(editor.id, shiftPressed())
+		target := gt.rowAt(next.Row)
+		sameRow := next.Row == editor.id.Row
+
+		edited := gt.commitEdit(editor)
+		if edited == nil {
+			return true
+		}
+		gt.cancelEdit()
+
+		if found {
+			if sameRow { // committing replaced the item, and the sort may have moved it
+				target = edited
+			}
+			if row := gt.rowOf(target); target != nil && row >= 0 {
+				gt.editCell(widget.TableCellID{Row: row, Col: next.Col})
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// commitEdit parses and validates the text against a copy of the item, replacing the original if it passes.
+// Returns the replacement or nil if the edit was rejected.
+func (gt *GenericTable[T]) commitEdit(editor *cellEditor) *T {
+
+	original := gt.rowAt(editor.id.Row)
+	if original == nil {
+		return nil
+	}
+
+	column := gt.shownColumn(editor.id.Col)
+	if column == nil {
+		return nil
+	}
+	field := column.field
+
+	if gt.isBusy() { // an action started while editing
+		editor.showError(errBusy)
+		return nil
+	}
+
+	edited := *original
+	err := field.SetFromString(&edited, editor.Text)
+	if err == nil && field.Validator != nil {
+		err = field.Validator(edited)
+	}
+	if validator := gt.itemValidator(); err == nil && validator != nil {
+		err = validator.Validate(edited)
+	}
+	if err == nil {
+		err = gt.ReplaceItem(original, &edited)
+	}
+	if err != nil {
+		editor.showError(err)
+		return nil
+	}
+	return &edited
+}
+
+func (gt *GenericTable[T]) cancelEdit() {
+
+	if gt.editor == nil {
+		return
+	}
+	gt.editor.popUp.Hide()
+	gt.editor = nil
+
+	if c := fyne.CurrentApp().Driver().CanvasForObject(gt.table); c != nil {
+		c.Focus(gt.table)
+	}
+}
+
+// nextEditableCell walks forwards or backwards through the cells, wrapping onto the adjacent rows
+func (gt *GenericTable[T]) nextEditableCell(from widget.TableCellID, backwards bool) (widget.TableCellID, bool) {
+
+	step := 1
+	if backwards {
+		step = -1
+	}
+
+	cols := gt.shownCount()
+	pos := from.Row*cols + from.Col
+	for {
+		pos += step
+		if pos < 0 || pos >= gt.rowCount()*cols {
+			return from, false
+		}
+		if gt.isEditable(pos % cols) {
+			return widget.TableCellID{Row: pos / cols, Col: pos % cols}, true
+		}
+	}
+}
+
+func (gt *GenericTable[T]) rowOf(item *T) int {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	if gt.pager != nil {
+		return gt.pager.rowOf(item)
+	}
+	return slices.Index(gt.rows, item)
+}
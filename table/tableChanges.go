@@ -0,0 +1,356 @@
+package table
+
+import (
+	"image/color"
+	"reflect"
+	"slices"
+
+	"fyne.io/fyne/v2/theme"
+)
+
+// ItemChange is a modified item with its value when tracking started or was last committed
+type ItemChange[T any] struct {
+	Item     *T
+	Original *T
+}
+
+// Changeset lists what changed in a table since tracking started or the last commit
+type Changeset[T any] struct {
+	Inserted []*T
+	Modified []ItemChange[T]
+	Deleted  []*T // as they were before being modified, if they were
+}
+
+func (cs Changeset[T]) IsEmpty() bool {
+	return len(cs.Inserted) == 0 && len(cs.Modified) == 0 && len(cs.Deleted) == 0
+}
+
+type rowState int
+
+const (
+	rowClean rowState = iota
+	rowInserted
+	rowModified
+)
+
+// changeTracker follows the changes reported by the source, keyed by the current item
+type changeTracker[T any] struct {
+	inserted map[*T]bool
+	original map[*T]*T // modified item to its original
+	deleted  map[*T]*T // deleted item to its original
+	order    []*T      // the deleted items in the order they went
+}
+
+func newChangeTracker[T any]() *changeTracker[T] {
+	return &changeTracker[T]{inserted: map[*T]bool{}, original: map[*T]*T{}, deleted: map[*T]*T{}}
+}
+
+func (ct *changeTracker[T]) apply(change DataChange[T]) {
+
+	switch change.Kind {
+	case ItemsInserted:
+		for _, item := range change.Items {
+			ct.itemInserted(item)
+		}
+	case ItemsUpdated:
+		for i, old := range change.Previous {
+			if i < len(change.Items) {
+				ct.itemUpdated(old, change.Items[i])
+			}
+		}
+	case ItemsDeleted:
+		for _, item := range change.Items {
+			ct.itemDeleted(item)
+		}
+	case ItemsReloaded: // the source was replaced wholesale, which becomes the new baseline
+		*ct = *newChangeTracker[T]()
+	}
+}
+
+func (ct *changeTracker[T]) itemInserted(item *T) {
+
+	original, ok := ct.deleted[item]
+	if !ok {
+		ct.inserted[item] = true
+		return
+	}
+	// a deleted item came back, as when undoing
+	delete(ct.deleted, item)
+	ct.order = slices.DeleteFunc(ct.order, func(other *T) bool { return other == item })
+	ct.setOriginal(item, original)
+}
+
+func (ct *changeTracker[T]) itemUpdated(old, item *T) {
+
+	if ct.inserted[old] {
+		delete(ct.inserted, old)
+		ct.inserted[item] = true
+		return
+	}
+	original := ct.originalOf(old)
+	delete(ct.original, old)
+	ct.setOriginal(item, original)
+}
+
+func (ct *changeTracker[T]) itemDeleted(item *T) {
+
+	if ct.inserted[item] {
+		delete(ct.inserted, item)
+		return
+	}
+	ct.deleted[item] = ct.originalOf(item)
+	delete(ct.original, item)
+	ct.order = append(ct.order, item)
+}
+
+// modifiedInPlace records a change made to the item itself, before being a copy taken beforehand
+func (ct *changeTracker[T]) modifiedInPlace(item, before *T) {
+
+	if ct.inserted[item] {
+		return
+	}
+	original, ok := ct.original[item]
+	if !ok {
+		original = before
+	}
+	delete(ct.original, item)
+	ct.setOriginal(item, original)
+}
+
+func (ct *changeTracker[T]) originalOf(item *T) *T {
+	if original, ok := ct.original[item]; ok {
+		return original
+	}
+	return item
+}
+
+// setOriginal records the item as modified unless it is back to its original value
+func (ct *changeTracker[T]) setOriginal(item, original *T) {
+	if item != original && !reflect.DeepEqual(*item, *original) {
+		ct.original[item] = original
+	}
+}
+
+func (ct *changeTracker[T]) stateOf(item *T) rowState {
+
+	switch {
+	case ct.inserted[item]:
+		return rowInserted
+	case ct.original[item] != nil:
+		return rowModified
+	}
+	return rowClean
+}
+
+// deletedAs finds the deleted item given either as it was deleted or as it originally was
+func (ct *changeTracker[T]) deletedAs(item *T) (*T, bool) {
+
+	if _, ok := ct.deleted[item]; ok {
+		return item, true
+	}
+	for gone, original := range ct.deleted {
+		if original == item {
+			return gone, true
+		}
+	}
+	return nil, false
+}
+
+// forget stops tracking the item, as if it had been committed
+func (ct *changeTracker[T]) forget(item *T) {
+	delete(ct.inserted, item)
+	delete(ct.original, item)
+	if gone, ok := ct.deletedAs(item); ok {
+		delete(ct.deleted, gone)
+		ct.order = slices.DeleteFunc(ct.order, func(other *T) bool { return other == gone })
+	}
+}
+
+// ==================== table =======================
+
+// Changes returns the items inserted, modified and deleted since the data was set or last committed,
+// the inserted and modified ones in the order of the data. Changes made to items in place are only seen
+// when made by actions or through ChangeInPlace.
+func (gt *GenericTable[T]) Changes() Changeset[T] {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	var changes Changeset[T]
+	for _, item := range gt.dataOrCachedLocked() {
+		switch gt.changes.stateOf(item) {
+		case rowInserted:
+			changes.Inserted = append(changes.Inserted, item)
+		case rowModified:
+			changes.Modified = append(changes.Modified, ItemChange[T]{Item: item, Original: gt.changes.original[item]})
+		}
+	}
+	for _, item := range gt.changes.order {
+		changes.Deleted = append(changes.Deleted, gt.changes.deleted[item])
+	}
+	return changes
+}
+
+// ChangeInPlace runs change, which modifies the items themselves, and records those it modified as if
+// they had been replaced. The table is refreshed if change returns true or modified any.
+func (gt *GenericTable[T]) ChangeInPlace(items []*T, change func([]*T) bool) bool {
+
+	before := snapshotOf(items)
+	changed := change(items)
+	if gt.changedInPlace(items, before) || changed {
+		gt.ItemsChanged()
+	}
+	return changed
+}
+
+// snapshotOf copies the items, to tell later what was changed in place
+func snapshotOf[T any](items []*T) []T {
+
+	copies := make([]T, len(items))
+	for i, item := range items {
+		copies[i] = *item
+	}
+	return copies
+}
+
+// changedInPlace compares the items to their copies, recording the modified ones. Returns whether there were any.
+func (gt *GenericTable[T]) changedInPlace(items []*T, before []T) bool {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	modified := false
+	for i, item := range items {
+		if !reflect.DeepEqual(*item, before[i]) {
+			gt.changes.modifiedInPlace(item, &before[i])
+			modified = true
+		}
+	}
+	return modified
+}
+
+// dataOrCachedLocked returns the fetched items, including the tracked ones not cached when paged
+func (gt *GenericTable[T]) dataOrCachedLocked() []*T {
+
+	if gt.pager == nil {
+		return gt.data
+	}
+	items := gt.pager.cached()
+	for item := range gt.changes.inserted {
+		if !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+	for item := range gt.changes.original {
+		if !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// IsDirty tells whether anything changed since the data was set or last committed
+func (gt *GenericTable[T]) IsDirty() bool {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return len(gt.changes.inserted)+len(gt.changes.original)+len(gt.changes.deleted) > 0
+}
+
+// IsItemDirty tells whether the item was inserted or modified
+func (gt *GenericTable[T]) IsItemDirty(item *T) bool {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.changes.stateOf(item) != rowClean
+}
+
+// Commit takes the current items as the baseline, typically once the changes were saved
+func (gt *GenericTable[T]) Commit() {
+
+	gt.mu.Lock()
+	gt.changes = newChangeTracker[T]()
+	gt.mu.Unlock()
+	gt.redraw()
+}
+
+// CommitItems takes the current state of the items, or the deletion of deleted ones, as their baseline
+func (gt *GenericTable[T]) CommitItems(items ...*T) {
+
+	gt.mu.Lock()
+	for _, item := range items {
+		gt.changes.forget(item)
+	}
+	gt.mu.Unlock()
+	gt.redraw()
+}
+
+// Revert undoes all the changes since the data was set or last committed. This clears the undo history.
+func (gt *GenericTable[T]) Revert() error {
+
+	changes := gt.Changes()
+
+	var items []*T
+	items = append(items, changes.Inserted...)
+	for _, modified := range changes.Modified {
+		items = append(items, modified.Item)
+	}
+	gt.mu.Lock()
+	items = append(items, gt.changes.order...)
+	gt.mu.Unlock()
+
+	return gt.RevertItems(items...)
+}
+
+// RevertItems restores the items to their baseline: inserted ones are deleted, modified ones get their original
+// value back and deleted ones, as listed in the changes, are inserted again. This clears the undo history.
+func (gt *GenericTable[T]) RevertItems(items ...*T) error {
+
+	source := gt.DataSource()
+	defer gt.clearHistory()
+
+	return gt.BatchUpdate(func() error {
+		for _, item := range items {
+			gt.mu.Lock()
+			state := gt.changes.stateOf(item)
+			original := gt.changes.original[item]
+			gone, deleted := gt.changes.deletedAs(item)
+			if deleted {
+				original = gt.changes.deleted[gone]
+			}
+			gt.mu.Unlock()
+
+			var err error
+			switch {
+			case state == rowInserted:
+				err = source.Delete([]*T{item})
+			case state == rowModified:
+				err = source.Update(item, original)
+			case deleted:
+				err = source.Insert(gone)
+				if err == nil && gone != original { // it was modified before being deleted
+					err = source.Update(gone, original)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// dirtyColor is the colour marking the cell, nil if it is unchanged
+func (gt *GenericTable[T]) dirtyColor(item *T, column *Column[T]) color.Color {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	switch gt.changes.stateOf(item) {
+	case rowInserted:
+		return theme.Color(theme.ColorNameSuccess)
+	case rowModified:
+		if column.StringValueFor(*item) != column.StringValueFor(*gt.changes.original[item]) {
+			return theme.Color(theme.ColorNameWarning)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package table
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetRowMenu shows the menu the builder returns for the selected items when a row is right-clicked,
+// the row is selected first unless it already is. A nil builder or menu shows nothing.
+func (gt *GenericTable[T]) SetRowMenu(builder func(selected []*T) *fyne.Menu) {
+	gt.rowMenu = builder
+}
+
+func (gt *GenericTable[T]) cellTappedSecondary(cell *TableCell, pos fyne.Position) {
+
+	item := gt.rowAt(cell.id.Row)
+	if item == nil || gt.rowMenu == nil {
+		return
+	}
+	if !gt.IsSelected(item) {
+		gt.cellTapped(cell)
+	}
+
+	menu := gt.rowMenu(gt.SelectedItems())
+	cnvs := fyne.CurrentApp().Driver().CanvasForObject(gt.table)
+	if menu == nil || len(menu.Items) == 0 || cnvs == nil {
+		return
+	}
+	widget.ShowPopUpMenuAtPosition(menu, cnvs, pos)
+}
+
+// ==================== container =======================
+
+// rowMenu lists the standard operations followed by the custom actions
+func (tc *TableContainer[T]) rowMenu(selected []*T) *fyne.Menu {
+
+	busy := tc.IsBusy()
+
+	edit := fyne.NewMenuItem("Edit", tc.handleEdit)
+	edit.Icon = theme.SettingsIcon()
+	edit.Disabled = busy
+
+	copyRows := fyne.NewMenuItem("Copy", func() { tc.CopySelectionToClipboard(fyne.CurrentApp()) })
+	copyRows.Icon = theme.ContentCopyIcon()
+
+	remove := fyne.NewMenuItem("Delete", tc.handleDelete)
+	remove.Icon = theme.DeleteIcon()
+	remove.Disabled = busy
+
+	items := []*fyne.MenuItem{edit, copyRows, remove}
+	if len(tc.customActions) > 0 {
+		items = append(items, fyne.NewMenuItemSeparator())
+		for idx, action := range tc.customActions {
+			items = append(items, tc.menuItemFor(action, idx, selected, busy))
+		}
+	}
+	return fyne.NewMenu("", items...)
+}
+
+// menuItemFor turns the action into a menu entry, idx is that of its button or -1
+func (tc *TableContainer[T]) menuItemFor(action ItemAction[T], idx int, selected []*T, busy bool) *fyne.MenuItem {
+
+	if action.Separator {
+		return fyne.NewMenuItemSeparator()
+	}
+
+	item := fyne.NewMenuItem(tc.actionName(action), func() { tc.runAction(action, idx) })
+	item.Icon = action.Icon
+	item.Disabled = (action.Action != nil || action.Async != nil) && !tc.canRun(action, idx, selected, busy)
+
+	if len(action.Submenu) > 0 {
+		children := make([]*fyne.MenuItem, len(action.Submenu))
+		for i, child := range action.Submenu {
+			children[i] = tc.menuItemFor(child, -1, selected, busy)
+		}
+		item.ChildMenu = fyne.NewMenu("", children...)
+	}
+	return item
+}
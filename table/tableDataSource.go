@@ -0,0 +1,229 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hooperbloob/fyne-components/meta"
+)
+
+// DataSource supplies the items of a table and applies the changes made through it.
+// Sources report changes made elsewhere through their change listeners so the table can refresh.
+type DataSource[T any] interface {
+	Count() (int, error)
+	Fetch(offset, limit int) ([]*T, error) // the items in [offset, offset+limit), fewer at the end
+	Insert(item *T) error
+	Update(old, item *T) error // replaces the old item with the new one
+	Delete(items []*T) error
+	AddChangeListener(listener func(DataChange[T])) (remove func())
+}
+
+// SortingSource is a source that orders its items itself, the table hands it the sort keys
+// instead of sorting locally
+type SortingSource[T any] interface {
+	SetSort(keys []SortField[T]) error
+}
+
+// FilteringSource is a source that restricts Count and Fetch to the matching items itself,
+// the table hands it the filter instead of filtering locally
+type FilteringSource[T any] interface {
+	SetFilter(filter SourceFilter[T]) error
+}
+
+// PositionalSource is a source that keeps its items in order, so undoing a delete can put the items back
+// where they were rather than at the end
+type PositionalSource[T any] interface {
+	IndexOf(item *T) int // -1 if it isn't there
+	InsertAt(index int, item *T) error
+}
+
+// SortField is a sort key resolved to the field of its column
+type SortField[T any] struct {
+	Field     *meta.FieldDescriptor[T]
+	Ascending bool
+}
+
+// SourceFilter carries the predicate, and for text searches what it was built from so a source can translate it
+type SourceFilter[T any] struct {
+	Match  func(T) bool // nil matches everything
+	Text   TextFilter   // Text.Text is empty unless the filter came from a text search
+	Fields []*meta.FieldDescriptor[T]
+}
+
+type ChangeKind int
+
+const (
+	ItemsInserted ChangeKind = iota
+	ItemsUpdated
+	ItemsDeleted
+	ItemsReloaded // anything may have changed
+)
+
+// DataChange describes a change to a source, for updates Previous holds the replaced items in the same order as Items.
+// Offset is the first row that may have changed or moved, the rows before it are kept when paged.
+// Sources that can't tell leave it 0.
+type DataChange[T any] struct {
+	Kind     ChangeKind
+	Items    []*T
+	Previous []*T
+	Offset   int
+}
+
+// fetchAll reads every item the source offers
+func fetchAll[T any](source DataSource[T]) ([]*T, error) {
+
+	count, err := source.Count()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	return source.Fetch(0, count)
+}
+
+// ==================== slice source =======================
+
+// SliceSource keeps the items in memory, leaving sorting and filtering to the table. It is safe for concurrent use.
+type SliceSource[T any] struct {
+	mu        sync.Mutex
+	items     []*T
+	listeners map[int]func(DataChange[T])
+	nextID    int
+}
+
+func NewSliceSource[T any](items []*T) *SliceSource[T] {
+	return &SliceSource[T]{items: items, listeners: map[int]func(DataChange[T]){}}
+}
+
+// Items returns a copy of the items
+func (ss *SliceSource[T]) Items() []*T {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return append([]*T(nil), ss.items...)
+}
+
+// SetItems replaces all the items
+func (ss *SliceSource[T]) SetItems(items []*T) {
+	ss.mu.Lock()
+	ss.items = items
+	ss.mu.Unlock()
+	ss.notify(DataChange[T]{Kind: ItemsReloaded})
+}
+
+func (ss *SliceSource[T]) Count() (int, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return len(ss.items), nil
+}
+
+func (ss *SliceSource[T]) Fetch(offset, limit int) ([]*T, error) {
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	offset = max(0, min(offset, len(ss.items)))
+	end := min(offset+max(limit, 0), len(ss.items))
+	return append([]*T(nil), ss.items[offset:end]...), nil
+}
+
+func (ss *SliceSource[T]) Insert(item *T) error {
+	ss.mu.Lock()
+	offset := len(ss.items)
+	ss.items = append(ss.items, item)
+	ss.mu.Unlock()
+	ss.notify(DataChange[T]{Kind: ItemsInserted, Items: []*T{item}, Offset: offset})
+	return nil
+}
+
+func (ss *SliceSource[T]) IndexOf(item *T) int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return slices.Index(ss.items, item)
+}
+
+// InsertAt puts the item before the one at the index, at the end if the index is past it
+func (ss *SliceSource[T]) InsertAt(index int, item *T) error {
+	ss.mu.Lock()
+	offset := max(0, min(index, len(ss.items)))
+	ss.items = slices.Insert(ss.items, offset, item)
+	ss.mu.Unlock()
+	ss.notify(DataChange[T]{Kind: ItemsInserted, Items: []*T{item}, Offset: offset})
+	return nil
+}
+
+func (ss *SliceSource[T]) Update(old, item *T) error {
+
+	ss.mu.Lock()
+	idx := slices.Index(ss.items, old)
+	if idx >= 0 {
+		ss.items[idx] = item
+	}
+	ss.mu.Unlock()
+
+	if idx < 0 {
+		return fmt.Errorf("The item to update is no longer present")
+	}
+	ss.notify(DataChange[T]{Kind: ItemsUpdated, Items: []*T{item}, Previous: []*T{old}, Offset: idx})
+	return nil
+}
+
+func (ss *SliceSource[T]) Delete(items []*T) error {
+
+	doomed := make(map[*T]bool, len(items))
+	for _, item := range items {
+		doomed[item] = true
+	}
+
+	ss.mu.Lock()
+	kept := make([]*T, 0, len(ss.items))
+	var deleted []*T
+	offset := 0
+	for i, item := range ss.items {
+		if doomed[item] {
+			if len(deleted) == 0 {
+				offset = i
+			}
+			deleted = append(deleted, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	if len(deleted) > 0 {
+		ss.items = kept
+	}
+	ss.mu.Unlock()
+
+	if len(deleted) > 0 {
+		ss.notify(DataChange[T]{Kind: ItemsDeleted, Items: deleted, Offset: offset})
+	}
+	return nil
+}
+
+func (ss *SliceSource[T]) AddChangeListener(listener func(DataChange[T])) func() {
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	id := ss.nextID
+	ss.nextID++
+	ss.listeners[id] = listener
+	return func() {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		delete(ss.listeners, id)
+	}
+}
+
+// notify tells the listeners about the change, outside the lock as they read the items back
+func (ss *SliceSource[T]) notify(change DataChange[T]) {
+
+	ss.mu.Lock()
+	listeners := make([]func(DataChange[T]), 0, len(ss.listeners))
+	for _, listener := range ss.listeners {
+		listeners = append(listeners, listener)
+	}
+	ss.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
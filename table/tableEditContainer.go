@@ -12,11 +12,19 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// ItemAction is a custom control acting on the selected items, either Action or Async is set
 type ItemAction[T any] struct {
-	Label   string
-	Icon    fyne.Resource
-	Action  func([]*T) bool // true if the context changed, then refresh req'd
-	Enabler func([]*T) bool
+	Label      string
+	Icon       fyne.Resource
+	Action     func([]*T) bool // true if the context changed, then refresh req'd
+	Undo       func([]*T) bool // reverts Action so it can be undone, optional
+	Async      AsyncAction[T]  // runs in the background with a progress display and a Cancel button
+	Enabler    func([]*T) bool
+	Concurrent bool                    // an async action leaving the items alone, other actions stay available while it runs
+	StatusBar  bool                    // show the progress of an async action below the table rather than in a dialog
+	Submenu    []ItemAction[T]         // shown nested in the row menu, without an Action there is no button
+	Separator  bool                    // a line between the entries of the row menu, the other fields are ignored
+	Shortcut   *desktop.CustomShortcut // runs the action, a Keymap passed to InstallKeymap takes precedence
 }
 
 // TableContainer wraps the GenericTable with controls
@@ -26,15 +34,24 @@ type TableContainer[T any] struct {
 	addButton      *widget.Button
 	editButton     *widget.Button
 	deleteButton   *widget.Button
+	exportButton   *widget.Button
+	importButton   *widget.Button
 	deleteAction   []ItemAction[T]
 	customActions  []ItemAction[T]
 	customControls []*widget.Button
+	search         *searchBar[T]
+	exporters      []Exporter[T]
+	running        []*actionRun[T]
+	status         *fyne.Container // progress of the async actions shown below the table
+	legend         *StatusLegend
+	keys           map[string]keyBinding // by shortcut name
 	container      *fyne.Container
 	window         fyne.Window
 	editItemFunc   func(*T, bool, int, func(T)) // Function to show add/edit dialog
 }
 
-// NewTableContainer creates a container with table and controls
+// NewTableContainer creates a container with table and controls.
+// Without an editItemFunc the add/edit dialog is generated from the table's columns.
 func NewTableContainer[T any](
 	table *GenericTable[T],
 	window fyne.Window,
@@ -45,41 +62,53 @@ func NewTableContainer[T any](
 		table:        table,
 		window:       window,
 		editItemFunc: editItemFunc,
+		exporters:    DefaultExporters[T](),
 	}
 
 	tc.addButton = widget.NewButtonWithIcon("", theme.ContentAddIcon(), tc.handleAdd)
 	tc.editButton = widget.NewButtonWithIcon("", theme.SettingsIcon(), tc.handleEdit)
 	tc.deleteButton = widget.NewButtonWithIcon("", theme.DeleteIcon(), tc.handleDelete)
+	tc.exportButton = widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), tc.ShowExportDialog)
+	tc.importButton = widget.NewButtonWithIcon("", theme.FolderOpenIcon(), tc.ShowImportDialog)
 	//tc.deleteButton.Importance = widget.DangerImportance
 
 	tc.customActions = actions
 	tc.customControls = make([]*widget.Button, len(actions))
 
 	for idx, act := range actions {
-		tc.customControls[idx] = tc.buttonFor(act, idx) // TODO allow for other control types
+		if act.Action != nil || act.Async != nil { // separators and submenus only show in the row menu
+			tc.customControls[idx] = tc.buttonFor(act, idx) // TODO allow for other control types
+		}
 		tc.customActions[idx] = act
 	}
 
 	tc.editButton.Disable()
 	tc.deleteButton.Disable()
 
-	// Update delete button state when selection changes
-	table.table.OnSelected = func(id widget.TableCellID) {
-		table.newSelectedRow(id.Row)
-		tc.updateEditButtons()
+	table.SetErrorHandler(tc.showError)
+	table.SetRowMenu(tc.rowMenu)
+	table.busy = tc.IsBusy
+	if table.History() == nil {
+		table.SetHistory(NewHistory(DefaultHistoryDepth))
 	}
 
-	table.table.OnUnselected = func(id widget.TableCellID) {
-		table.selectedRows.Remove(id.Row)
+	// Update delete button state when selection changes
+	table.AddSelectionListener(func([]*T) {
 		tc.updateEditButtons()
-	}
+	})
 
 	controls := tc.createControls()
 	controlContainer := container.NewVBox(controls...)
 
+	tc.search = newSearchBar(table)
+	tc.status = container.NewVBox()
+	tc.status.Hide()
+	tc.legend = NewStatusLegend()
+	tc.legend.Hide()
+
 	tc.container = container.NewBorder(
-		nil,
-		nil,
+		tc.search.content,
+		container.NewVBox(tc.legend, tc.status),
 		nil,
 		controlContainer,
 		table,
@@ -109,147 +138,151 @@ func (tc *TableContainer[T]) AdjustColumns() {
 
 func (tc *TableContainer[T]) updateEditButtons() {
 
-	selections := tc.table.SelectedItemsByIdx()
-	if len(selections) > 0 {
-		tc.editButton.Enable()
-		tc.deleteButton.Enable()
+	selections := tc.table.SelectedItems()
+	busy := tc.IsBusy()
+
+	setEnabled(tc.editButton, len(selections) > 0 && !busy)
+	setEnabled(tc.deleteButton, len(selections) > 0 && !busy)
+	setEnabled(tc.addButton, !busy)
+	setEnabled(tc.importButton, !busy)
+	tc.enableCustom(selections, busy)
+}
+
+func setEnabled(button *widget.Button, enabled bool) {
+	if enabled {
+		button.Enable()
 	} else {
-		tc.deleteButton.Disable()
-		tc.editButton.Disable()
+		button.Disable()
 	}
-	tc.enableCustom(valuesOf(selections))
 }
 
 func (tc *TableContainer[T]) createControls() []fyne.CanvasObject {
 
-	cbCount := len(tc.customControls)
-	controls := make([]fyne.CanvasObject, cbCount+5)
-	controls[0] = tc.addButton
-	controls[1] = tc.editButton
-	controls[2] = widget.NewSeparator()
-	for i, cb := range tc.customControls {
-		controls[i+3] = cb
+	controls := []fyne.CanvasObject{tc.addButton, tc.editButton, widget.NewSeparator()}
+	for _, cb := range tc.customControls {
+		if cb != nil {
+			controls = append(controls, cb)
+		}
 	}
-	controls[cbCount+3] = layout.NewSpacer()
-	controls[cbCount+4] = tc.deleteButton
-	return controls
+	return append(controls, layout.NewSpacer(), tc.importButton, tc.exportButton, tc.deleteButton)
 }
 
-func valuesOf[T any](mapp map[int]*T) []*T {
+// enableCustom enables the actions applicable to the values, while busy only the concurrent ones
+func (tc *TableContainer[T]) enableCustom(values []*T, busy bool) {
 
-	values := make([]*T, len(mapp))
-	i := 0
-	for _, val := range mapp {
-		values[i] = val
-		i++
+	for idx, control := range tc.customControls {
+		if control != nil {
+			setEnabled(control, tc.canRun(tc.customActions[idx], idx, values, busy))
+		}
 	}
-	return values
 }
 
-func (tc *TableContainer[T]) enableCustom(values []*T) {
+// canRun tells whether the action applies to the values and may start now
+func (tc *TableContainer[T]) canRun(action ItemAction[T], idx int, values []*T, busy bool) bool {
 
-	for idx, control := range tc.customControls {
-		if len(values) > 0 {
-			enabler := tc.customActions[idx].Enabler
-			if enabler != nil {
-				enabled := enabler(values)
-				if enabled {
-					control.Enable()
-					continue
-				}
-			}
-		} else {
-			control.Disable()
-		}
+	enabled := len(values) > 0 && !tc.isRunning(idx) && (!busy || action.Concurrent)
+	if enabled && action.Enabler != nil {
+		enabled = action.Enabler(values)
 	}
+	return enabled
 }
 
 // handleAdd shows dialog to add new item
 func (tc *TableContainer[T]) handleAdd() {
+	if tc.IsBusy() {
+		return
+	}
 	newItem := tc.table.newItemFunc()
-	tc.editItemFunc(&newItem, true, -1, func(edited T) {
-		tc.table.AddItem(&edited)
+	tc.editItem(&newItem, true, -1, func(edited T) {
+		tc.showError(tc.table.InsertItem(&edited))
 	})
 }
 
-func firstValueOf[T any](items map[int]T) (int, T) {
-	for i, item := range items {
-		return i, item
+func (tc *TableContainer[T]) handleEdit() {
+	if tc.IsBusy() {
+		return
+	}
+	selectedItems := tc.table.SelectedItems()
+	if len(selectedItems) == 0 {
+		return
 	}
-	var zero T // won't get here, allows it to compile
-	return -1, zero
+	item := selectedItems[0]
+	idx := tc.table.indexOf(item)
+
+	tc.editItem(item, false, idx, func(edited T) {
+		tc.showError(tc.table.ReplaceItem(item, &edited))
+	})
 }
 
-func (tc *TableContainer[T]) handleEdit() {
-	selectedItems := tc.table.SelectedItemsByIdx()
-	idx, item := firstValueOf(selectedItems)
+func (tc *TableContainer[T]) editItem(item *T, isAdd bool, idx int, callback func(T)) {
 
-	tc.editItemFunc(item, false, idx, func(edited T) {
-		tc.table.ItemEdited(idx, &edited)
-	})
+	if tc.editItemFunc != nil {
+		tc.editItemFunc(item, isAdd, idx, callback)
+		return
+	}
+	NewItemForm(tc.table.columns, tc.table.itemValidator(), tc.window).Show(item, isAdd, callback)
 }
 
 // handleDelete deletes selected items with confirmation
 func (tc *TableContainer[T]) handleDelete() {
 	count := tc.table.GetSelectedCount()
-	if count == 0 {
+	if count == 0 || tc.IsBusy() {
 		return
 	}
 
 	message := fmt.Sprintf("Delete %d selected item(s)?", count)
 	dialog.ShowConfirm("Confirm Delete", message, func(confirmed bool) {
 		if confirmed {
-			tc.table.DeleteSelected()
+			_, err := tc.table.DeleteSelectedItems()
+			tc.showError(err)
 			tc.updateEditButtons()
 		}
 	}, tc.window)
 }
 
+// showError reports the error, if any
+func (tc *TableContainer[T]) showError(err error) {
+	if err != nil {
+		dialog.ShowError(err, tc.window)
+	}
+}
+
+// Redraw shows changes made to the items in place, it may be called from any goroutine
 func (tc *TableContainer[T]) Redraw() {
-	tc.table.Refresh()
+	tc.table.ItemsChanged()
 }
 
 func (tc *TableContainer[T]) handleCustom(actionIdx int) {
-
-	action := tc.customActions[actionIdx]
-	selectedItems := valuesOf(tc.table.SelectedItemsByIdx())
-
-	if action.Action(selectedItems) {
-		tc.table.Refresh()
-	}
+	tc.runAction(tc.customActions[actionIdx], actionIdx)
 }
 
-// HandleKeyboard processes keyboard shortcuts
-func (tc *TableContainer[T]) HandleKeyboard(event *fyne.KeyEvent) {
-	// Simple Delete key for all platforms
-	if event.Name == fyne.KeyDelete || event.Name == fyne.KeyBackspace {
-		if tc.table.GetSelectedCount() > 0 {
-			tc.handleDelete()
-		}
-	}
-}
+// runAction applies the action to the selected items, idx is that of its button or -1 if it has none
+func (tc *TableContainer[T]) runAction(action ItemAction[T], idx int) {
 
-// TypedShortcut handles keyboard shortcuts with modifiers
-func (tc *TableContainer[T]) TypedShortcut(shortcut fyne.Shortcut) {
-	if _, ok := shortcut.(*desktop.CustomShortcut); ok {
-		// Handle custom shortcuts if needed
+	if action.Async != nil {
+		tc.runAsync(action, idx)
 		return
 	}
+	if action.Action == nil {
+		return
+	}
+	selectedItems := tc.table.SelectedItems()
 
-	// Check for Ctrl+N or Cmd+N
-	if _, ok := shortcut.(*fyne.ShortcutCopy); !ok {
-		// Try to match our add shortcut
-		if key, ok := shortcut.(fyne.KeyboardShortcut); ok {
-			if key.Key() == fyne.KeyN {
-				tc.handleAdd()
-			}
-		}
+	tc.table.ChangeInPlace(selectedItems, action.Action)
+	if action.Undo != nil {
+		tc.table.record(&actionCommand[T]{gt: tc.table, action: action, items: selectedItems})
+		tc.updateEditButtons()
 	}
 }
 
+// CopySelectionToClipboard puts the selected rows on the clipboard as TSV, ready to paste into a spreadsheet
 func (tc *TableContainer[T]) CopySelectionToClipboard(app fyne.App) {
 
-	content := tc.table.SelectionAsString("\t", "\n")
+	content, err := tc.table.ExportCSV(TSVOptions(SelectedRows))
+	if err != nil {
+		dialog.ShowError(err, tc.window)
+		return
+	}
 	app.Clipboard().SetContent(content)
 }
 
@@ -0,0 +1,284 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+type RowScope int
+
+const (
+	SelectedRows RowScope = iota
+	VisibleRows           // all rows passing the filter
+	AllRows               // including the rows hidden by the filter
+)
+
+func (scope RowScope) String() string {
+
+	switch scope {
+	case SelectedRows:
+		return "Selected rows"
+	case VisibleRows:
+		return "Visible rows"
+	}
+	return "All rows"
+}
+
+// ExportOptions determine what is written and how, RFC 4180 CSV by default
+type ExportOptions struct {
+	Scope     RowScope
+	Separator rune // defaults to a comma
+	Headers   bool // write the column labels first
+	Columns   []int
+	UseCRLF   bool
+}
+
+// CSVOptions is comma separated with a header line and CRLF line endings, as per RFC 4180
+func CSVOptions(scope RowScope) ExportOptions {
+	return ExportOptions{Scope: scope, Separator: ',', Headers: true, UseCRLF: true}
+}
+
+// TSVOptions is tab separated without headers, the way spreadsheets put rows on the clipboard
+func TSVOptions(scope RowScope) ExportOptions {
+	return ExportOptions{Scope: scope, Separator: '\t'}
+}
+
+// ItemsIn returns the items of the scope in display order, rows hidden by the filter are sorted
+// as they would be if shown. Sources that filter themselves only offer the matching rows,
+// and paged ones are read in full.
+func (gt *GenericTable[T]) ItemsIn(scope RowScope) []*T {
+
+	if scope == SelectedRows {
+		return gt.SelectedItems()
+	}
+
+	gt.mu.Lock()
+	if gt.pager != nil { // the source filters and sorts, so all of its rows are the visible ones
+		source := gt.source
+		gt.mu.Unlock()
+
+		items, err := fetchAll(source)
+		if err != nil {
+			gt.reportError(err)
+		}
+		return items
+	}
+	defer gt.mu.Unlock()
+
+	if scope == VisibleRows {
+		return append([]*T(nil), gt.rows...)
+	}
+	items := append([]*T(nil), gt.data...)
+	gt.sortRowsLocked(items)
+	return items
+}
+
+// exportOrder returns the indices of all columns in display order, the shown columns as arranged followed by
+// the hidden ones
+func (gt *GenericTable[T]) exportOrder() []int {
+
+	gt.mu.Lock()
+	order := slices.Clone(gt.order)
+	gt.mu.Unlock()
+	for idx := range gt.columns {
+		if !slices.Contains(order, idx) {
+			order = append(order, idx)
+		}
+	}
+	return order
+}
+
+// exportColumns resolves the column indices in display order, all columns being exported when none are given
+func (gt *GenericTable[T]) exportColumns(indices []int) []Column[T] {
+
+	columns := make([]Column[T], 0, len(gt.columns))
+	for _, idx := range gt.exportOrder() {
+		if len(indices) == 0 || slices.Contains(indices, idx) {
+			columns = append(columns, gt.columns[idx])
+		}
+	}
+	return columns
+}
+
+// WriteCSV writes the rows with quoting wherever values contain separators, quotes or line breaks
+func (gt *GenericTable[T]) WriteCSV(w io.Writer, opts ExportOptions) error {
+
+	columns := gt.exportColumns(opts.Columns)
+
+	writer := csv.NewWriter(w)
+	if opts.Separator != 0 {
+		writer.Comma = opts.Separator
+	}
+	writer.UseCRLF = opts.UseCRLF
+
+	record := make([]string, len(columns))
+
+	if opts.Headers {
+		for i, col := range columns {
+			record[i] = col.field.Label
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range gt.ItemsIn(opts.Scope) {
+		for i, col := range columns {
+			record[i] = col.StringValueFor(*item)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportCSV returns the CSV text for the options
+func (gt *GenericTable[T]) ExportCSV(opts ExportOptions) (string, error) {
+
+	var sb strings.Builder
+	err := gt.WriteCSV(&sb, opts)
+	return sb.String(), err
+}
+
+// SelectionAsString returns the selected rows with the separators between columns and after each line.
+//
+// Deprecated: use ExportCSV with TSVOptions(SelectedRows), which quotes values as spreadsheets expect.
+func (gt *GenericTable[T]) SelectionAsString(columnSeparator string, lineSeparator string) string {
+
+	opts := TSVOptions(SelectedRows)
+	if separator := []rune(columnSeparator); len(separator) == 1 {
+		opts.Separator = separator[0]
+	}
+	opts.UseCRLF = lineSeparator == "\r\n"
+
+	text, err := gt.ExportCSV(opts)
+	if err != nil {
+		gt.reportError(err)
+	}
+	return text
+}
+
+// ==================== export dialog =======================
+
+// SetExporters replaces the formats offered by the export dialog
+func (tc *TableContainer[T]) SetExporters(exporters []Exporter[T]) {
+	tc.exporters = exporters
+}
+
+// ShowExportDialog asks what to export and then where to save it
+func (tc *TableContainer[T]) ShowExportDialog() {
+
+	if len(tc.exporters) == 0 {
+		return
+	}
+
+	names := make([]string, len(tc.exporters))
+	for i, exporter := range tc.exporters {
+		names[i] = exporter.Name()
+	}
+	format := widget.NewSelect(names, nil)
+	format.SetSelectedIndex(0)
+
+	scopes := []string{SelectedRows.String(), VisibleRows.String(), AllRows.String()}
+	scope := widget.NewRadioGroup(scopes, nil)
+	scope.Required = true
+	if tc.table.GetSelectedCount() > 0 {
+		scope.SetSelected(SelectedRows.String())
+	} else {
+		scope.SetSelected(VisibleRows.String())
+	}
+
+	headers := widget.NewCheck("", nil)
+	headers.SetChecked(true)
+
+	labels := make([]string, len(tc.table.columns))
+	var shown []string
+	for i, col := range tc.table.columns {
+		labels[i] = col.field.Label
+		if tc.table.IsColumnShown(i) {
+			shown = append(shown, col.field.Label)
+		}
+	}
+	columns := widget.NewCheckGroup(labels, nil)
+	columns.SetSelected(shown)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Format", format),
+		widget.NewFormItem("Rows", scope),
+		widget.NewFormItem("Headers", headers),
+		widget.NewFormItem("Columns", columns),
+	)
+	exportDialog := dialog.NewCustomWithoutButtons("Export", form, tc.window)
+
+	saveButton := widget.NewButton("Save", func() {
+		exportDialog.Hide()
+
+		opts := ExportOptions{
+			Scope:   RowScope(slices.Index(scopes, scope.Selected)),
+			Headers: headers.Checked,
+			Columns: selectedIndices(labels, columns.Selected),
+		}
+
+		exporter := tc.exporters[format.SelectedIndex()]
+		tc.saveExport(exporter.Extension(), func(w io.Writer) error {
+			return exporter.Export(w, tc.table, opts)
+		})
+	})
+	saveButton.Importance = widget.HighImportance
+
+	columns.OnChanged = func(selected []string) { // no columns would mean all of them
+		if len(selected) == 0 {
+			saveButton.Disable()
+		} else {
+			saveButton.Enable()
+		}
+	}
+	columns.OnChanged(columns.Selected)
+
+	exportDialog.SetButtons([]fyne.CanvasObject{widget.NewButton("Cancel", exportDialog.Hide), saveButton})
+	exportDialog.Show()
+}
+
+// saveExport asks for a file to save to and fills it using the writer function
+func (tc *TableContainer[T]) saveExport(extension string, write func(io.Writer) error) {
+
+	saveDialog := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, tc.window)
+			return
+		}
+		if file == nil {
+			return // cancelled
+		}
+		defer file.Close()
+
+		if err := write(file); err != nil {
+			dialog.ShowError(err, tc.window)
+		}
+	}, tc.window)
+
+	saveDialog.SetFileName("export." + extension)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + extension}))
+	saveDialog.Show()
+}
+
+func selectedIndices(options []string, selected []string) []int {
+
+	var indices []int
+	for i, option := range options {
+		if slices.Contains(selected, option) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
@@ -0,0 +1,294 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Exporter writes the rows of a table in a particular format, usable from the export dialog or directly
+type Exporter[T any] interface {
+	Name() string
+	Extension() string
+	Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error
+}
+
+// DefaultExporters are the formats offered by a TableContainer unless replaced
+func DefaultExporters[T any]() []Exporter[T] {
+
+	return []Exporter[T]{
+		CSVExporter[T]{},
+		TSVExporter[T]{},
+		JSONExporter[T]{Indent: "  "},
+		MarkdownExporter[T]{},
+		HTMLExporter[T]{},
+		XLSXExporter[T]{},
+	}
+}
+
+// ExportTo writes the rows with the exporter into a string
+func ExportTo[T any](exporter Exporter[T], table *GenericTable[T], opts ExportOptions) (string, error) {
+
+	var sb strings.Builder
+	err := exporter.Export(&sb, table, opts)
+	return sb.String(), err
+}
+
+// ==================== CSV / TSV =======================
+
+type CSVExporter[T any] struct{}
+
+func (CSVExporter[T]) Name() string      { return "CSV" }
+func (CSVExporter[T]) Extension() string { return "csv" }
+
+func (CSVExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+	opts.Separator = ','
+	opts.UseCRLF = true
+	return table.WriteCSV(w, opts)
+}
+
+type TSVExporter[T any] struct{}
+
+func (TSVExporter[T]) Name() string      { return "TSV" }
+func (TSVExporter[T]) Extension() string { return "tsv" }
+
+func (TSVExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+	opts.Separator = '\t'
+	return table.WriteCSV(w, opts)
+}
+
+// ==================== JSON =======================
+
+// JSONExporter writes an array of objects keyed by column label in column order, labels repeated being numbered,
+// or of the items themselves marshalled as is when RawStructs is set. Numbers and booleans keep their types.
+type JSONExporter[T any] struct {
+	RawStructs bool
+	Indent     string
+}
+
+func (JSONExporter[T]) Name() string      { return "JSON" }
+func (JSONExporter[T]) Extension() string { return "json" }
+
+func (je JSONExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+
+	items := table.ItemsIn(opts.Scope)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", je.Indent)
+	encoder.SetEscapeHTML(false)
+	if je.RawStructs {
+		return encoder.Encode(items)
+	}
+
+	columns := table.exportColumns(opts.Columns)
+	keys := uniqueLabels(columns)
+
+	objects := make([]jsonObject, len(items))
+	for i, item := range items {
+		object := make(jsonObject, len(columns))
+		for c, col := range columns {
+			object[c] = jsonMember{key: keys[c], value: jsonValueFor(col, *item)}
+		}
+		objects[i] = object
+	}
+	return encoder.Encode(objects)
+}
+
+// uniqueLabels numbers the repeats of column labels, so they can be used as keys
+func uniqueLabels[T any](columns []Column[T]) []string {
+
+	labels := make([]string, len(columns))
+	seen := map[string]int{}
+	for i, col := range columns {
+		label := col.field.Label
+		for seen[label] > 0 {
+			seen[col.field.Label]++
+			label = fmt.Sprintf("%s %d", col.field.Label, seen[col.field.Label])
+		}
+		seen[label]++
+		labels[i] = label
+	}
+	return labels
+}
+
+// jsonValueFor is the typed value of the column as JSON can hold it, times as RFC 3339 text
+func jsonValueFor[T any](col Column[T], item T) any {
+
+	switch v := cellValueFor(col, item).(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return col.StringValueFor(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonObject is an object marshalled with its members in order
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	key   string
+	value any
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, member := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encoder.Encode(member.key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode ends with a newline
+		buf.WriteByte(':')
+		if err := encoder.Encode(member.value); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ==================== Markdown =======================
+
+// MarkdownExporter writes a GitHub flavoured table, which always has a header
+type MarkdownExporter[T any] struct{}
+
+func (MarkdownExporter[T]) Name() string      { return "Markdown" }
+func (MarkdownExporter[T]) Extension() string { return "md" }
+
+func (MarkdownExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+
+	columns := table.exportColumns(opts.Columns)
+	bw := bufio.NewWriter(w)
+
+	cells := make([]string, len(columns))
+	for i, col := range columns {
+		cells[i] = markdownEscape(col.field.Label)
+	}
+	writeMarkdownRow(bw, cells)
+
+	for i, col := range columns {
+		switch col.alignment {
+		case fyne.TextAlignCenter:
+			cells[i] = ":---:"
+		case fyne.TextAlignTrailing:
+			cells[i] = "---:"
+		default:
+			cells[i] = ":---"
+		}
+	}
+	writeMarkdownRow(bw, cells)
+
+	for _, item := range table.ItemsIn(opts.Scope) {
+		for i, col := range columns {
+			cells[i] = markdownEscape(col.StringValueFor(*item))
+		}
+		writeMarkdownRow(bw, cells)
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownRow(bw *bufio.Writer, cells []string) {
+	bw.WriteString("| ")
+	bw.WriteString(strings.Join(cells, " | "))
+	bw.WriteString(" |\n")
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+func markdownEscape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// ==================== HTML =======================
+
+// HTMLExporter writes a standalone page, keeping the column alignment and the status colours of icon columns
+type HTMLExporter[T any] struct {
+	Title string
+}
+
+func (HTMLExporter[T]) Name() string      { return "HTML" }
+func (HTMLExporter[T]) Extension() string { return "html" }
+
+func (he HTMLExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+
+	columns := table.exportColumns(opts.Columns)
+	title := he.Title
+	if title == "" {
+		title = "Export"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	bw.WriteString("<style>table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:4px 8px}th{background:#eee}</style>\n")
+	bw.WriteString("</head>\n<body>\n<table>\n")
+
+	if opts.Headers {
+		bw.WriteString("<thead><tr>")
+		for _, col := range columns {
+			fmt.Fprintf(bw, "<th style=\"text-align:%s\">%s</th>", cssAlign(col.alignment), html.EscapeString(col.field.Label))
+		}
+		bw.WriteString("</tr></thead>\n")
+	}
+
+	bw.WriteString("<tbody>\n")
+	for _, item := range table.ItemsIn(opts.Scope) {
+		bw.WriteString("<tr>")
+		for _, col := range columns {
+			if col.IsIcon() {
+				status := col.StatusFor(*item)
+				fmt.Fprintf(bw, "<td style=\"text-align:center\" title=\"%s\"><span style=\"color:%s\">%s</span></td>",
+					html.EscapeString(status.Tooltip), cssColor(status.Color), status.glyph())
+				continue
+			}
+			fmt.Fprintf(bw, "<td style=\"text-align:%s\">%s</td>", cssAlign(col.alignment), html.EscapeString(col.StringValueFor(*item)))
+		}
+		bw.WriteString("</tr>\n")
+	}
+	bw.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+	return bw.Flush()
+}
+
+func cssAlign(align fyne.TextAlign) string {
+
+	switch align {
+	case fyne.TextAlignCenter:
+		return "center"
+	case fyne.TextAlignTrailing:
+		return "right"
+	}
+	return "left"
+}
+
+func cssColor(clr color.Color) string {
+
+	if clr == nil {
+		return "transparent"
+	}
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return fmt.Sprintf("rgba(%d,%d,%d,%.2f)", c.R, c.G, c.B, float64(c.A)/255)
+}
@@ -0,0 +1,176 @@
+package table
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const AllColumns = -1
+
+// TextFilter matches items whose column values contain the text, or match it as a regular expression
+type TextFilter struct {
+	Text          string
+	Column        int // index of the column to search in, or AllColumns for the ones shown
+	CaseSensitive bool
+	Regex         bool
+}
+
+// matcherFor builds the predicate for the filter settings over the columns searched, nil if there is nothing to match on
+func matcherFor[T any](tf TextFilter, scope []Column[T]) (func(T) bool, error) {
+
+	if tf.Text == "" {
+		return nil, nil
+	}
+
+	var matches func(string) bool
+
+	if tf.Regex {
+		pattern := tf.Text
+		if !tf.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		matches = re.MatchString
+	} else if tf.CaseSensitive {
+		matches = func(value string) bool { return strings.Contains(value, tf.Text) }
+	} else {
+		text := strings.ToLower(tf.Text)
+		matches = func(value string) bool { return strings.Contains(strings.ToLower(value), text) }
+	}
+
+	return func(item T) bool {
+		for _, col := range scope {
+			if matches(col.field.Accessor(item)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// filterScope returns the columns searched by the filter, all columns meaning the ones shown
+func (gt *GenericTable[T]) filterScope(tf TextFilter) []Column[T] {
+
+	if tf.Column != AllColumns && tf.Column >= 0 && tf.Column < len(gt.columns) {
+		return gt.columns[tf.Column : tf.Column+1]
+	}
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	scope := make([]Column[T], len(gt.order))
+	for i, idx := range gt.order {
+		scope[i] = gt.columns[idx]
+	}
+	return scope
+}
+
+// SetTextFilter shows only the rows matching the text filter, leaving the current filter in place if the pattern is invalid
+func (gt *GenericTable[T]) SetTextFilter(tf TextFilter) error {
+
+	scope := gt.filterScope(tf)
+	matcher, err := matcherFor(tf, scope)
+	if err != nil {
+		return err
+	}
+
+	filter := SourceFilter[T]{Match: matcher}
+	if matcher != nil {
+		filter.Text = tf
+		for _, col := range scope {
+			filter.Fields = append(filter.Fields, col.field)
+		}
+	}
+	gt.applyFilter(filter)
+	return nil
+}
+
+// refreshTextFilter applies the current text filter again if the columns it searches have changed
+func (gt *GenericTable[T]) refreshTextFilter() {
+
+	gt.mu.Lock()
+	tf, fields := gt.filter.Text, gt.filter.Fields
+	gt.mu.Unlock()
+
+	if tf.Text == "" {
+		return
+	}
+	scope := gt.filterScope(tf)
+	if len(scope) == len(fields) && !slices.ContainsFunc(scope, func(col Column[T]) bool { return !slices.Contains(fields, col.field) }) {
+		return
+	}
+	if err := gt.SetTextFilter(tf); err != nil {
+		gt.reportError(err)
+	}
+}
+
+// ========================== Search Bar =========================
+
+type searchBar[T any] struct {
+	entry      *widget.Entry
+	scope      *widget.Select
+	caseCheck  *widget.Check
+	regexCheck *widget.Check
+	table      *GenericTable[T]
+	content    fyne.CanvasObject
+}
+
+const allColumnsOption = "All columns"
+
+func newSearchBar[T any](table *GenericTable[T]) *searchBar[T] {
+
+	sb := &searchBar[T]{table: table}
+
+	options := make([]string, len(table.columns)+1)
+	options[0] = allColumnsOption
+	for i, col := range table.columns {
+		options[i+1] = col.field.Label
+	}
+
+	sb.entry = widget.NewEntry()
+	sb.entry.SetPlaceHolder("Search")
+	sb.entry.OnChanged = func(string) { sb.apply() }
+	sb.entry.Validator = func(text string) error {
+		if sb.regexCheck.Checked {
+			_, err := regexp.Compile(text)
+			return err
+		}
+		return nil
+	}
+
+	sb.scope = widget.NewSelect(options, func(string) { sb.apply() })
+	sb.scope.SetSelectedIndex(0)
+	sb.caseCheck = widget.NewCheck("Aa", func(bool) { sb.apply() })
+	sb.regexCheck = widget.NewCheck(".*", func(bool) {
+		sb.entry.Validate()
+		sb.apply()
+	})
+
+	sb.content = container.NewBorder(nil, nil, sb.scope, container.NewHBox(sb.caseCheck, sb.regexCheck), sb.entry)
+	return sb
+}
+
+func (sb *searchBar[T]) filter() TextFilter {
+
+	return TextFilter{
+		Text:          sb.entry.Text,
+		Column:        sb.scope.SelectedIndex() - 1, // the first option covers all columns
+		CaseSensitive: sb.caseCheck.Checked,
+		Regex:         sb.regexCheck.Checked,
+	}
+}
+
+func (sb *searchBar[T]) apply() {
+	if sb.entry == nil || sb.scope == nil || sb.caseCheck == nil || sb.regexCheck == nil {
+		return // still under construction
+	}
+	sb.entry.SetValidationError(sb.table.SetTextFilter(sb.filter()))
+}
@@ -0,0 +1,105 @@
+package table
+
+import (
+	"reflect"
+
+	"github.com/hooperbloob/fyne-components/meta"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ItemForm builds add/edit dialogs with an entry for every editable column
+type ItemForm[T any] struct {
+	typeName  string
+	columns   []Column[T]
+	validator meta.Validator[T]
+	window    fyne.Window
+}
+
+func NewItemForm[T any](columns []Column[T], validator meta.Validator[T], window fyne.Window) *ItemForm[T] {
+
+	return &ItemForm[T]{
+		typeName:  reflect.TypeFor[T]().Name(),
+		columns:   columns,
+		validator: validator,
+		window:    window,
+	}
+}
+
+// EditItemFunc adapts the form to the editing function of a TableContainer
+func (form *ItemForm[T]) EditItemFunc() func(*T, bool, int, func(T)) {
+
+	return func(item *T, isAdd bool, _ int, callback func(T)) {
+		form.Show(item, isAdd, callback)
+	}
+}
+
+// Show opens the dialog on a copy of the item, fields without entries keep their values
+func (form *ItemForm[T]) Show(item *T, isAdd bool, callback func(T)) {
+
+	title := "Edit " + form.typeName
+	if isAdd {
+		title = "Add " + form.typeName
+	}
+
+	var fields []*meta.FieldDescriptor[T]
+	var entries []*widget.Entry
+	var formItems []*widget.FormItem
+
+	for _, col := range form.columns {
+		if col.IsIcon() || !col.field.IsEditable() {
+			continue
+		}
+		field := col.field
+
+		entry := widget.NewEntry()
+		entry.SetText(field.StringValueFor(*item))
+		entry.Validator = func(text string) error {
+			candidate := *item
+			if err := field.SetFromString(&candidate, text); err != nil {
+				return err
+			}
+			if field.Validator != nil {
+				return field.Validator(candidate)
+			}
+			return nil
+		}
+
+		fields = append(fields, field)
+		entries = append(entries, entry)
+		formItems = append(formItems, widget.NewFormItem(field.Label, entry))
+	}
+
+	dialog.ShowForm(title, "Save", "Cancel", formItems, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		edited := *item
+		for i, field := range fields {
+			if err := field.SetFromString(&edited, entries[i].Text); err != nil {
+				form.showRejected(err, &edited, isAdd, callback)
+				return
+			}
+		}
+		if form.validator != nil {
+			if err := form.validator.Validate(edited); err != nil {
+				form.showRejected(err, &edited, isAdd, callback)
+				return
+			}
+		}
+		callback(edited)
+	}, form.window)
+}
+
+// showRejected reports why the item as a whole failed and reopens the form with the values entered
+func (form *ItemForm[T]) showRejected(err error, edited *T, isAdd bool, callback func(T)) {
+
+	errDialog := dialog.NewError(err, form.window)
+	errDialog.SetOnClosed(func() {
+		form.Show(edited, isAdd, callback)
+	})
+	errDialog.Show()
+}
@@ -0,0 +1,425 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// DefaultHistoryDepth is the number of changes a TableContainer can undo unless changed
+const DefaultHistoryDepth = 100
+
+// Command is a change that can be undone and applied again
+type Command interface {
+	Name() string
+	Undo() error
+	Redo() error
+}
+
+// History keeps the undoable commands, the most recent last. It is safe for concurrent use.
+type History struct {
+	mu         sync.Mutex
+	done       []Command
+	undone     []Command
+	depth      int
+	group      *commandGroup // collects the commands while grouping
+	groupDepth int
+	replaying  bool // commands aren't recorded while undoing or redoing
+}
+
+func NewHistory(depth int) *History {
+	return &History{depth: max(depth, 1)}
+}
+
+// Record adds a command that was just carried out, which drops the commands undone before
+func (h *History) Record(cmd Command) {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case h.replaying:
+	case h.group != nil:
+		h.group.commands = append(h.group.commands, cmd)
+	default:
+		h.pushLocked(cmd)
+	}
+}
+
+func (h *History) pushLocked(cmd Command) {
+
+	h.undone = nil
+	h.done = append(h.done, cmd)
+	if excess := len(h.done) - h.depth; excess > 0 {
+		h.done = h.done[excess:]
+	}
+}
+
+// Group runs fn, recording the commands it carries out as a single one. Groups may nest, the outermost one records.
+func (h *History) Group(name string, fn func() error) error {
+
+	h.mu.Lock()
+	if h.group == nil {
+		h.group = &commandGroup{name: name}
+	}
+	h.groupDepth++
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.groupDepth--
+		if h.groupDepth > 0 {
+			return
+		}
+		group := h.group
+		h.group = nil
+		if len(group.commands) > 0 {
+			h.pushLocked(group)
+		}
+	}()
+
+	return fn()
+}
+
+// Undo reverts the most recent command, keeping it if that fails. A command that fails part way and can't
+// be put back as it was is dropped, as neither undoing nor redoing it would be safe.
+func (h *History) Undo() error {
+
+	h.mu.Lock()
+	if len(h.done) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	cmd := h.done[len(h.done)-1]
+	h.done = h.done[:len(h.done)-1]
+	h.replaying = true
+	h.mu.Unlock()
+
+	err := cmd.Undo()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.replaying = false
+	var halfDone *halfDoneError
+	switch {
+	case errors.As(err, &halfDone):
+		return fmt.Errorf("Undoing %s failed part way, so it was dropped from the history: %w", cmd.Name(), err)
+	case err != nil:
+		h.done = append(h.done, cmd)
+		return fmt.Errorf("Undoing %s failed: %w", cmd.Name(), err)
+	}
+	h.undone = append(h.undone, cmd)
+	return nil
+}
+
+// Redo applies the most recently undone command again, keeping it if that fails or dropping it as Undo does
+func (h *History) Redo() error {
+
+	h.mu.Lock()
+	if len(h.undone) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	cmd := h.undone[len(h.undone)-1]
+	h.undone = h.undone[:len(h.undone)-1]
+	h.replaying = true
+	h.mu.Unlock()
+
+	err := cmd.Redo()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.replaying = false
+	var halfDone *halfDoneError
+	switch {
+	case errors.As(err, &halfDone):
+		return fmt.Errorf("Redoing %s failed part way, so it was dropped from the history: %w", cmd.Name(), err)
+	case err != nil:
+		h.undone = append(h.undone, cmd)
+		return fmt.Errorf("Redoing %s failed: %w", cmd.Name(), err)
+	}
+	h.done = append(h.done, cmd)
+	return nil
+}
+
+func (h *History) CanUndo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.done) > 0
+}
+
+func (h *History) CanRedo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.undone) > 0
+}
+
+// UndoNames names the commands that can be undone, the next one first
+func (h *History) UndoNames() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return namesOf(h.done)
+}
+
+// RedoNames names the commands that can be redone, the next one first
+func (h *History) RedoNames() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return namesOf(h.undone)
+}
+
+func namesOf(commands []Command) []string {
+
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[len(commands)-1-i] = cmd.Name()
+	}
+	return names
+}
+
+// SetDepth limits the number of commands kept, dropping the oldest ones
+func (h *History) SetDepth(depth int) {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.depth = max(depth, 1)
+	if excess := len(h.done) - h.depth; excess > 0 {
+		h.done = h.done[excess:]
+	}
+	if excess := len(h.undone) - h.depth; excess > 0 {
+		h.undone = h.undone[excess:]
+	}
+}
+
+func (h *History) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.done, h.undone = nil, nil
+}
+
+// ==================== commands =======================
+
+// halfDoneError is a command that failed part way and couldn't be put back as it was
+type halfDoneError struct {
+	err error
+}
+
+func (e *halfDoneError) Error() string { return e.err.Error() }
+func (e *halfDoneError) Unwrap() error { return e.err }
+
+type commandGroup struct {
+	name     string
+	commands []Command
+}
+
+func (cg *commandGroup) Name() string { return cg.name }
+
+// Undo reverts the commands last to first, if one fails those already reverted are applied again
+func (cg *commandGroup) Undo() error {
+	for i := len(cg.commands) - 1; i >= 0; i-- {
+		if err := cg.commands[i].Undo(); err != nil {
+			return rollBack(err, cg.commands[i+1:], Command.Redo)
+		}
+	}
+	return nil
+}
+
+// Redo applies the commands first to last, if one fails those already applied are reverted
+func (cg *commandGroup) Redo() error {
+	for i, cmd := range cg.commands {
+		if err := cmd.Redo(); err != nil {
+			done := slices.Clone(cg.commands[:i])
+			slices.Reverse(done)
+			return rollBack(err, done, Command.Undo)
+		}
+	}
+	return nil
+}
+
+// rollBack puts the commands back as they were after one failed with err
+func rollBack(err error, commands []Command, restore func(Command) error) error {
+
+	var halfDone *halfDoneError
+	if errors.As(err, &halfDone) {
+		return err
+	}
+	for _, cmd := range commands {
+		if restoreErr := restore(cmd); restoreErr != nil {
+			return &halfDoneError{err: errors.Join(err, restoreErr)}
+		}
+	}
+	return err
+}
+
+// insertCommand added items to the source, deleteCommand removed them
+type insertCommand[T any] struct {
+	gt     *GenericTable[T]
+	source DataSource[T]
+	items  []*T
+}
+
+func (ic *insertCommand[T]) Name() string { return "Add" }
+func (ic *insertCommand[T]) Undo() error  { return ic.source.Delete(ic.items) }
+func (ic *insertCommand[T]) Redo() error  { return ic.gt.insertAll(ic.source, ic.items, nil) }
+
+type deleteCommand[T any] struct {
+	gt        *GenericTable[T]
+	source    DataSource[T]
+	items     []*T
+	positions []int // where the items were in a PositionalSource, ascending, nil for other sources
+}
+
+func (dc *deleteCommand[T]) Name() string { return fmt.Sprintf("Delete %d item(s)", len(dc.items)) }
+func (dc *deleteCommand[T]) Undo() error  { return dc.gt.insertAll(dc.source, dc.items, dc.positions) }
+func (dc *deleteCommand[T]) Redo() error  { return dc.source.Delete(dc.items) }
+
+// newDeleteCommand records the items about to be deleted, with their positions if the source keeps them
+func newDeleteCommand[T any](gt *GenericTable[T], source DataSource[T], items []*T) *deleteCommand[T] {
+
+	dc := &deleteCommand[T]{gt: gt, source: source, items: items}
+	positional, ok := source.(PositionalSource[T])
+	if !ok {
+		return dc
+	}
+
+	type placed struct {
+		item     *T
+		position int
+	}
+	all := make([]placed, len(items))
+	for i, item := range items {
+		all[i] = placed{item, positional.IndexOf(item)}
+	}
+	slices.SortStableFunc(all, func(a, b placed) int { return a.position - b.position })
+
+	dc.items = make([]*T, len(all))
+	dc.positions = make([]int, len(all))
+	for i, p := range all {
+		dc.items[i], dc.positions[i] = p.item, p.position
+	}
+	return dc
+}
+
+// insertAll adds the items back, at their positions if given and otherwise at the end unless the table is sorted.
+// The positions have to be ascending so each item lands where it was. If one can't be added the ones that were
+// are deleted again.
+func (gt *GenericTable[T]) insertAll(source DataSource[T], items []*T, positions []int) error {
+
+	positional, _ := source.(PositionalSource[T])
+	insert := func(i int) error {
+		if positional != nil && i < len(positions) && positions[i] >= 0 {
+			return positional.InsertAt(positions[i], items[i])
+		}
+		return source.Insert(items[i])
+	}
+
+	return gt.BatchUpdate(func() error {
+		for i := range items {
+			if err := insert(i); err != nil {
+				if i == 0 {
+					return err
+				}
+				if deleteErr := source.Delete(items[:i]); deleteErr != nil {
+					return &halfDoneError{err: errors.Join(err, deleteErr)}
+				}
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type replaceCommand[T any] struct {
+	source    DataSource[T]
+	old, item *T
+}
+
+func (rc *replaceCommand[T]) Name() string { return "Edit" }
+func (rc *replaceCommand[T]) Undo() error  { return rc.source.Update(rc.item, rc.old) }
+func (rc *replaceCommand[T]) Redo() error  { return rc.source.Update(rc.old, rc.item) }
+
+// actionCommand is a custom action that declared its inverse
+type actionCommand[T any] struct {
+	gt     *GenericTable[T]
+	action ItemAction[T]
+	items  []*T
+}
+
+func (ac *actionCommand[T]) Name() string { return ac.action.Label }
+
+func (ac *actionCommand[T]) Undo() error {
+	ac.gt.ChangeInPlace(ac.items, ac.action.Undo)
+	return nil
+}
+
+func (ac *actionCommand[T]) Redo() error {
+	ac.gt.ChangeInPlace(ac.items, ac.action.Action)
+	return nil
+}
+
+// ==================== table =======================
+
+// SetHistory records the changes made through the table in the history, nil stops recording
+func (gt *GenericTable[T]) SetHistory(history *History) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.history = history
+}
+
+func (gt *GenericTable[T]) History() *History {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.history
+}
+
+func (gt *GenericTable[T]) record(cmd Command) {
+	if history := gt.History(); history != nil {
+		history.Record(cmd)
+	}
+}
+
+// clearHistory forgets the changes once the items they refer to are gone
+func (gt *GenericTable[T]) clearHistory() {
+	if history := gt.History(); history != nil {
+		history.Clear()
+	}
+}
+
+// inHistoryGroup runs fn as a single undoable change
+func (gt *GenericTable[T]) inHistoryGroup(name string, fn func() error) error {
+
+	run := func() error { return gt.BatchUpdate(fn) }
+	if history := gt.History(); history != nil {
+		return history.Group(name, run)
+	}
+	return run()
+}
+
+// ==================== container =======================
+
+// History returns the undo history of the changes made through the container
+func (tc *TableContainer[T]) History() *History {
+	return tc.table.History()
+}
+
+// Undo reverts the most recent change
+func (tc *TableContainer[T]) Undo() {
+
+	if history := tc.History(); history != nil && !tc.IsBusy() {
+		tc.showError(history.Undo())
+		tc.updateEditButtons()
+	}
+}
+
+// Redo applies the most recently undone change again
+func (tc *TableContainer[T]) Redo() {
+
+	if history := tc.History(); history != nil && !tc.IsBusy() {
+		tc.showError(history.Redo())
+		tc.updateEditButtons()
+	}
+}
@@ -0,0 +1,49 @@
+package table
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUndoDeleteKeepsOrder(t *testing.T) {
+
+	items := []*pasteItem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+	gt := newPasteTable(t, slices.Clone(items))
+	history := NewHistory(10)
+	gt.SetHistory(history)
+
+	gt.SetSelectedItems([]*pasteItem{items[3], items[0], items[2]})
+	if _, err := gt.DeleteSelectedItems(); err != nil {
+		t.Fatal(err)
+	}
+	source := gt.DataSource().(*SliceSource[pasteItem])
+	if got := source.Items(); !slices.Equal(got, []*pasteItem{items[1], items[4]}) {
+		t.Fatalf("%d items left after the delete", len(got))
+	}
+
+	if err := history.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := source.Items(); !slices.Equal(got, items) {
+		t.Errorf("undo restored %v", names(got))
+	}
+
+	if err := history.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := source.Items(); !slices.Equal(got, items) {
+		t.Errorf("undo after redo restored %v", names(got))
+	}
+}
+
+func names(items []*pasteItem) []string {
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
@@ -0,0 +1,301 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// KeyCommand names an operation of a TableContainer that shortcuts can trigger
+type KeyCommand string
+
+const (
+	CommandAdd       KeyCommand = "add"
+	CommandEdit      KeyCommand = "edit"
+	CommandDelete    KeyCommand = "delete"
+	CommandCopy      KeyCommand = "copy"
+	CommandPaste     KeyCommand = "paste"
+	CommandSelectAll KeyCommand = "selectAll"
+	CommandFind      KeyCommand = "find"
+	CommandUndo      KeyCommand = "undo"
+	CommandRedo      KeyCommand = "redo"
+)
+
+var builtInCommands = []KeyCommand{CommandAdd, CommandEdit, CommandDelete, CommandCopy, CommandPaste,
+	CommandSelectAll, CommandFind, CommandUndo, CommandRedo}
+
+// ActionCommand is the command running the custom action with the label
+func ActionCommand(label string) KeyCommand {
+	return KeyCommand("action:" + label)
+}
+
+// Keymap binds shortcuts to the commands, those without a modifier being plain keys.
+// An empty list unbinds the command.
+type Keymap map[KeyCommand][]*desktop.CustomShortcut
+
+// DefaultKeymap binds the usual keys, Command takes the place of Control on macOS
+func DefaultKeymap() Keymap {
+
+	shortcut := func(key fyne.KeyName, modifier fyne.KeyModifier) *desktop.CustomShortcut {
+		return &desktop.CustomShortcut{KeyName: key, Modifier: modifier}
+	}
+	mod := fyne.KeyModifierShortcutDefault
+
+	return Keymap{
+		CommandAdd:       {shortcut(fyne.KeyN, mod)},
+		CommandEdit:      {shortcut(fyne.KeyF2, 0), shortcut(fyne.KeyE, mod)},
+		CommandDelete:    {shortcut(fyne.KeyDelete, 0), shortcut(fyne.KeyBackspace, 0)},
+		CommandCopy:      {shortcut(fyne.KeyC, mod)},
+		CommandPaste:     {shortcut(fyne.KeyV, mod)},
+		CommandSelectAll: {shortcut(fyne.KeyA, mod)},
+		CommandFind:      {shortcut(fyne.KeyF, mod)},
+		CommandUndo:      {shortcut(fyne.KeyZ, mod)},
+		CommandRedo:      {shortcut(fyne.KeyZ, mod|fyne.KeyModifierShift), shortcut(fyne.KeyY, mod)},
+	}
+}
+
+// KeymapConflictError lists the shortcuts bound more than once, only their first binding is installed
+type KeymapConflictError struct {
+	Conflicts []string
+}
+
+func (e *KeymapConflictError) Error() string {
+	return "Conflicting shortcuts: " + strings.Join(e.Conflicts, ", ")
+}
+
+// keyBinding is an installed shortcut
+type keyBinding struct {
+	shortcut *desktop.CustomShortcut
+	command  KeyCommand
+}
+
+// InstallKeymap registers the default keymap, the shortcuts of the custom actions and the overrides on the window.
+// Plain keys work while the table or nothing has the focus, it replaces the typed key handler of the canvas.
+// Shortcuts bound twice, or already used by the main menu, are reported in a KeymapConflictError.
+func (tc *TableContainer[T]) InstallKeymap(window fyne.Window, overrides Keymap) error {
+
+	taken := map[string]string{} // shortcut name to what it triggers
+	if menu := window.MainMenu(); menu != nil {
+		for _, m := range menu.Items {
+			menuShortcuts(m, taken)
+		}
+	}
+
+	keys, conflicts := tc.bindKeymap(overrides, taken)
+	tc.keys = keys
+	for _, binding := range keys {
+		if binding.shortcut.Modifier&^fyne.KeyModifierShift == 0 {
+			continue // arrives as a typed key
+		}
+		for _, shortcut := range canvasShortcuts(binding.shortcut) {
+			window.Canvas().AddShortcut(shortcut, tc.TypedShortcut)
+		}
+	}
+
+	window.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) { tc.typedKey(event) })
+	tc.table.keyHandler = tc.typedKey
+
+	if len(conflicts) > 0 {
+		return &KeymapConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// Keymap returns the shortcuts installed, or those that would be
+func (tc *TableContainer[T]) Keymap() Keymap {
+
+	keymap := Keymap{}
+	for _, binding := range tc.bindings() {
+		keymap[binding.command] = append(keymap[binding.command], binding.shortcut)
+	}
+	return keymap
+}
+
+// bindKeymap merges the keymaps, leaving out the shortcuts already taken
+func (tc *TableContainer[T]) bindKeymap(overrides Keymap, taken map[string]string) (map[string]keyBinding, []string) {
+
+	keymap := DefaultKeymap()
+	for _, action := range tc.allActions() {
+		if action.Shortcut != nil {
+			keymap[ActionCommand(action.Label)] = []*desktop.CustomShortcut{action.Shortcut}
+		}
+	}
+	for command, shortcuts := range overrides {
+		keymap[command] = shortcuts
+	}
+
+	var others []KeyCommand
+	for command := range keymap {
+		if !slices.Contains(builtInCommands, command) {
+			others = append(others, command)
+		}
+	}
+	slices.Sort(others) // conflicts are resolved the same way every time
+
+	keys := map[string]keyBinding{}
+	var conflicts []string
+	for _, command := range append(slices.Clone(builtInCommands), others...) {
+		for _, shortcut := range keymap[command] {
+			name := shortcutName(shortcut)
+			if other, ok := taken[name]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%s is bound to %s and %s", describeShortcut(shortcut), other, command))
+				continue
+			}
+			taken[name] = string(command)
+			keys[name] = keyBinding{shortcut: shortcut, command: command}
+		}
+	}
+	return keys, conflicts
+}
+
+// bindings are the installed shortcuts, the default ones until InstallKeymap is called
+func (tc *TableContainer[T]) bindings() map[string]keyBinding {
+	if tc.keys == nil {
+		tc.keys, _ = tc.bindKeymap(nil, map[string]string{})
+	}
+	return tc.keys
+}
+
+// TypedShortcut runs the command bound to the shortcut
+func (tc *TableContainer[T]) TypedShortcut(shortcut fyne.Shortcut) {
+
+	name := shortcut.ShortcutName()
+	if custom, ok := shortcut.(*desktop.CustomShortcut); ok {
+		name = shortcutName(custom)
+	}
+	if binding, ok := tc.bindings()[name]; ok {
+		tc.RunCommand(binding.command)
+	}
+}
+
+// typedKey runs the command bound to the key, with shift if held, false if there is none
+func (tc *TableContainer[T]) typedKey(event *fyne.KeyEvent) bool {
+
+	pressed := &desktop.CustomShortcut{KeyName: event.Name, Modifier: currentModifiers() & fyne.KeyModifierShift}
+	binding, ok := tc.bindings()[shortcutName(pressed)]
+	if ok {
+		tc.RunCommand(binding.command)
+	}
+	return ok
+}
+
+// HandleKeyboard runs the command bound to the typed key.
+//
+// Deprecated: InstallKeymap sets up the keys.
+func (tc *TableContainer[T]) HandleKeyboard(event *fyne.KeyEvent) {
+	tc.typedKey(event)
+}
+
+// RunCommand carries out the command as if its shortcut was typed
+func (tc *TableContainer[T]) RunCommand(command KeyCommand) {
+
+	switch command {
+	case CommandAdd:
+		tc.handleAdd()
+	case CommandEdit:
+		tc.handleEdit()
+	case CommandDelete:
+		tc.handleDelete()
+	case CommandCopy:
+		tc.CopySelectionToClipboard(fyne.CurrentApp())
+	case CommandPaste:
+		tc.PasteFromClipboard(fyne.CurrentApp())
+	case CommandSelectAll:
+		tc.SelectAll()
+	case CommandFind:
+		if cnvs := fyne.CurrentApp().Driver().CanvasForObject(tc.search.entry); cnvs != nil {
+			cnvs.Focus(tc.search.entry)
+		}
+	case CommandUndo:
+		tc.Undo()
+	case CommandRedo:
+		tc.Redo()
+	default:
+		selected := tc.table.SelectedItems()
+		for idx, action := range tc.allActions() {
+			if idx >= len(tc.customActions) {
+				idx = -1 // only in a submenu
+			}
+			if ActionCommand(action.Label) == command && tc.canRun(action, idx, selected, tc.IsBusy()) {
+				tc.runAction(action, idx)
+				return
+			}
+		}
+	}
+}
+
+// allActions lists the custom actions, those of the buttons first followed by the ones in submenus
+func (tc *TableContainer[T]) allActions() []ItemAction[T] {
+
+	actions := slices.Clone(tc.customActions)
+	for i := 0; i < len(actions); i++ {
+		actions = append(actions, actions[i].Submenu...)
+	}
+	return actions
+}
+
+// menuShortcuts collects the shortcuts of the menu and its submenus
+func menuShortcuts(menu *fyne.Menu, taken map[string]string) {
+
+	for _, item := range menu.Items {
+		if item.Shortcut != nil {
+			taken[item.Shortcut.ShortcutName()] = "menu " + item.Label
+		}
+		if item.ChildMenu != nil {
+			menuShortcuts(item.ChildMenu, taken)
+		}
+	}
+}
+
+// canvasShortcuts returns the shortcut as the drivers report it, they turn the standard
+// editing shortcuts into their own types
+func canvasShortcuts(shortcut *desktop.CustomShortcut) []fyne.Shortcut {
+
+	shortcuts := []fyne.Shortcut{shortcut}
+	if shortcut.Modifier != fyne.KeyModifierShortcutDefault {
+		return shortcuts
+	}
+	switch shortcut.KeyName {
+	case fyne.KeyZ:
+		shortcuts = append(shortcuts, &fyne.ShortcutUndo{})
+	case fyne.KeyY:
+		shortcuts = append(shortcuts, &fyne.ShortcutRedo{})
+	case fyne.KeyC:
+		shortcuts = append(shortcuts, &fyne.ShortcutCopy{})
+	case fyne.KeyV:
+		shortcuts = append(shortcuts, &fyne.ShortcutPaste{})
+	case fyne.KeyX:
+		shortcuts = append(shortcuts, &fyne.ShortcutCut{})
+	case fyne.KeyA:
+		shortcuts = append(shortcuts, &fyne.ShortcutSelectAll{})
+	}
+	return shortcuts
+}
+
+// shortcutName identifies the shortcut by the name the driver reports it with
+func shortcutName(shortcut *desktop.CustomShortcut) string {
+	shortcuts := canvasShortcuts(shortcut)
+	return shortcuts[len(shortcuts)-1].ShortcutName()
+}
+
+func describeShortcut(shortcut *desktop.CustomShortcut) string {
+
+	var parts []string
+	for _, mod := range []struct {
+		modifier fyne.KeyModifier
+		name     string
+	}{
+		{fyne.KeyModifierControl, "Ctrl"},
+		{fyne.KeyModifierAlt, "Alt"},
+		{fyne.KeyModifierShift, "Shift"},
+		{fyne.KeyModifierSuper, "Cmd"},
+	} {
+		if shortcut.Modifier&mod.modifier != 0 {
+			parts = append(parts, mod.name)
+		}
+	}
+	return strings.Join(append(parts, string(shortcut.KeyName)), "+")
+}
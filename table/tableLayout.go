@@ -0,0 +1,338 @@
+package table
+
+import (
+	"encoding/json"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TableLayout is the arrangement of the columns chosen by the user, which are identified by their IDs
+type TableLayout struct {
+	Order  []string           `json:"order"` // the shown columns from left to right
+	Hidden []string           `json:"hidden,omitempty"`
+	Widths map[string]float32 `json:"widths,omitempty"`
+	Sort   []LayoutSortKey    `json:"sort,omitempty"`
+}
+
+type LayoutSortKey struct {
+	Column    string `json:"column"`
+	Ascending bool   `json:"ascending"`
+}
+
+const layoutPreferencePrefix = "table.layout."
+
+// Layout returns the current arrangement of the columns
+func (gt *GenericTable[T]) Layout() TableLayout {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	layout := TableLayout{Widths: map[string]float32{}}
+	for _, idx := range gt.order {
+		layout.Order = append(layout.Order, gt.columns[idx].ID())
+	}
+	for idx, col := range gt.columns {
+		if !slices.Contains(gt.order, idx) {
+			layout.Hidden = append(layout.Hidden, col.ID())
+		}
+		layout.Widths[col.ID()] = gt.widths[idx]
+	}
+	for _, key := range gt.sortKeys {
+		layout.Sort = append(layout.Sort, LayoutSortKey{Column: gt.columns[key.Column].ID(), Ascending: key.Ascending})
+	}
+	return layout
+}
+
+// SetLayout arranges the columns, IDs it doesn't know are ignored and columns it doesn't mention are shown at the end.
+// Layouts saved when columns were identified by label still apply.
+func (gt *GenericTable[T]) SetLayout(layout TableLayout) {
+
+	gt.mu.Lock()
+	order := make([]int, 0, len(gt.columns))
+	for _, id := range layout.Order {
+		if idx := gt.columnWithIDLocked(id); idx >= 0 && !slices.Contains(order, idx) {
+			order = append(order, idx)
+		}
+	}
+	hidden := make([]int, 0, len(layout.Hidden))
+	for _, id := range layout.Hidden {
+		hidden = append(hidden, gt.columnWithIDLocked(id))
+	}
+	for idx := range gt.columns {
+		if !slices.Contains(order, idx) && !slices.Contains(hidden, idx) {
+			order = append(order, idx)
+		}
+	}
+	if len(order) > 0 {
+		gt.order = order
+	}
+	for id, width := range layout.Widths {
+		if idx := gt.columnWithIDLocked(id); idx >= 0 && width > 0 {
+			gt.widths[idx] = width
+		}
+	}
+	var keys []SortKey
+	for _, key := range layout.Sort {
+		if idx := gt.columnWithIDLocked(key.Column); idx >= 0 {
+			keys = append(keys, SortKey{Column: idx, Ascending: key.Ascending})
+		}
+	}
+	gt.mu.Unlock()
+
+	gt.SetSort(keys)
+	gt.columnsChanged()
+}
+
+// columnWithIDLocked finds the column by its ID, or failing that by its label, -1 if there is none
+func (gt *GenericTable[T]) columnWithIDLocked(id string) int {
+
+	if idx := slices.IndexFunc(gt.columns, func(col Column[T]) bool { return col.ID() == id }); idx >= 0 {
+		return idx
+	}
+	return slices.IndexFunc(gt.columns, func(col Column[T]) bool { return col.field.Label == id })
+}
+
+// SaveLayout stores the layout in the preferences under the table ID
+func (gt *GenericTable[T]) SaveLayout(prefs fyne.Preferences, id string) {
+
+	content, err := json.Marshal(gt.Layout())
+	if err != nil {
+		fyne.LogError("Saving the table layout failed", err)
+		return
+	}
+	prefs.SetString(layoutPreferencePrefix+id, string(content))
+}
+
+// RestoreLayout applies the layout stored under the table ID, false if there is none
+func (gt *GenericTable[T]) RestoreLayout(prefs fyne.Preferences, id string) bool {
+
+	content := prefs.String(layoutPreferencePrefix + id)
+	if content == "" {
+		return false
+	}
+	var layout TableLayout
+	if err := json.Unmarshal([]byte(content), &layout); err != nil {
+		fyne.LogError("Restoring the table layout failed", err)
+		return false
+	}
+	gt.SetLayout(layout)
+	return true
+}
+
+// PersistLayout restores the layout saved under the table ID in the app preferences
+// and saves it there whenever the user changes it
+func (gt *GenericTable[T]) PersistLayout(id string) {
+
+	gt.RestoreLayout(fyne.CurrentApp().Preferences(), id)
+
+	gt.mu.Lock()
+	gt.layoutID = id
+	gt.mu.Unlock()
+}
+
+// layoutChanged saves the layout if it is persisted
+func (gt *GenericTable[T]) layoutChanged() {
+
+	gt.mu.Lock()
+	id := gt.layoutID
+	gt.mu.Unlock()
+
+	if id != "" {
+		gt.SaveLayout(fyne.CurrentApp().Preferences(), id)
+	}
+}
+
+// ResetLayout shows all the columns in their original order and widths
+func (gt *GenericTable[T]) ResetLayout() {
+
+	gt.mu.Lock()
+	gt.resetColumnsLocked()
+	gt.mu.Unlock()
+
+	gt.columnsChanged()
+	gt.layoutChanged()
+}
+
+func (gt *GenericTable[T]) resetColumnsLocked() {
+
+	gt.order = make([]int, len(gt.columns))
+	gt.widths = make([]float32, len(gt.columns))
+	for idx, col := range gt.columns {
+		gt.order[idx] = idx
+		gt.widths[idx] = float32(col.width)
+	}
+}
+
+// ==================== shown columns =======================
+
+// shownCount is the number of columns shown
+func (gt *GenericTable[T]) shownCount() int {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return len(gt.order)
+}
+
+// columnIndex returns the index of the column shown at the position, -1 if there is none
+func (gt *GenericTable[T]) columnIndex(col int) int {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	if col < 0 || col >= len(gt.order) {
+		return -1
+	}
+	return gt.order[col]
+}
+
+// shownColumn returns the column shown at the position, nil if there is none
+func (gt *GenericTable[T]) shownColumn(col int) *Column[T] {
+
+	idx := gt.columnIndex(col)
+	if idx < 0 {
+		return nil
+	}
+	return &gt.columns[idx]
+}
+
+// IsColumnShown tells whether the column, by its index in the columns the table was created with, is shown
+func (gt *GenericTable[T]) IsColumnShown(columnIdx int) bool {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return slices.Contains(gt.order, columnIdx)
+}
+
+// ShowColumn shows or hides the column, the last one shown can't be hidden
+func (gt *GenericTable[T]) ShowColumn(columnIdx int, show bool) {
+
+	gt.mu.Lock()
+	pos := slices.Index(gt.order, columnIdx)
+	switch {
+	case columnIdx < 0 || columnIdx >= len(gt.columns):
+	case show && pos < 0:
+		gt.order = append(gt.order, columnIdx)
+	case !show && pos >= 0 && len(gt.order) > 1:
+		gt.order = slices.Delete(gt.order, pos, pos+1)
+	default:
+		gt.mu.Unlock()
+		return
+	}
+	gt.mu.Unlock()
+
+	gt.columnsChanged()
+	gt.layoutChanged()
+}
+
+// MoveColumn moves the column shown at one position to another
+func (gt *GenericTable[T]) MoveColumn(from, to int) {
+
+	gt.mu.Lock()
+	if from == to || from < 0 || from >= len(gt.order) || to < 0 || to >= len(gt.order) {
+		gt.mu.Unlock()
+		return
+	}
+	idx := gt.order[from]
+	gt.order = slices.Insert(slices.Delete(gt.order, from, from+1), to, idx)
+	gt.mu.Unlock()
+
+	gt.columnsChanged()
+	gt.layoutChanged()
+}
+
+// columnsChanged applies the widths to the shown columns and redraws them, searching the shown ones
+// again if a text filter covers them
+func (gt *GenericTable[T]) columnsChanged() {
+
+	gt.cancelEdit()
+	gt.unselectCells()
+	gt.SetColumnWidths()
+	gt.refreshTextFilter()
+	gt.redraw()
+}
+
+// columnResized records a width the user dragged a column to
+func (gt *GenericTable[T]) columnResized(col int, width float32) {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	if col >= 0 && col < len(gt.order) {
+		gt.widths[gt.order[col]] = width
+	}
+}
+
+// ==================== header interaction =======================
+
+// showColumnMenu lists the columns to show or hide
+func (gt *GenericTable[T]) showColumnMenu(pos fyne.Position) {
+
+	cnvs := fyne.CurrentApp().Driver().CanvasForObject(gt.table)
+	if cnvs == nil {
+		return
+	}
+
+	var items []*fyne.MenuItem
+	for idx, col := range gt.columns {
+		shown := gt.IsColumnShown(idx)
+		item := fyne.NewMenuItem(col.field.Label, func() { gt.ShowColumn(idx, !shown) })
+		item.Checked = shown
+		item.Disabled = shown && gt.shownCount() == 1
+		items = append(items, item)
+	}
+	items = append(items,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Show all columns", func() {
+			for idx := range gt.columns {
+				gt.ShowColumn(idx, true)
+			}
+		}),
+		fyne.NewMenuItem("Reset columns", gt.ResetLayout),
+	)
+
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), cnvs, pos)
+}
+
+// headerDragged tracks a header being dragged to another position
+func (gt *GenericTable[T]) headerDragged(header *HeaderLabel, col int, event *fyne.DragEvent) {
+
+	if !header.dragging {
+		header.dragging = true
+		header.Importance = widget.HighImportance
+		header.Refresh()
+	}
+	header.dragX = event.Position.X
+}
+
+// headerDropped moves the column to where its header was dropped
+func (gt *GenericTable[T]) headerDropped(header *HeaderLabel, col int) {
+
+	header.dragging = false
+	header.Importance = widget.MediumImportance
+	header.Refresh()
+
+	gt.MoveColumn(col, gt.dropTarget(col, header.dragX))
+}
+
+// dropTarget finds the position of the column under x, relative to the leading edge of the column at from
+func (gt *GenericTable[T]) dropTarget(from int, x float32) int {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	padding := theme.Padding()
+	widthOf := func(col int) float32 { return gt.widths[gt.order[col]] }
+
+	to := from
+	if x >= 0 {
+		for edge := widthOf(from) + padding; to+1 < len(gt.order) && x >= edge; to++ {
+			edge += widthOf(to+1) + padding
+		}
+		return to
+	}
+	for edge := -padding; to > 0 && x < edge; to-- {
+		edge -= widthOf(to-1) + padding
+	}
+	return to
+}
@@ -0,0 +1,242 @@
+package table
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+type SelectionMode int
+
+const (
+	SelectMulti  SelectionMode = iota // ctrl-click toggles rows, shift-click and shift with the keys select ranges
+	SelectSingle                      // at most one row
+	SelectNone                        // rows can't be selected, the cursor still moves
+)
+
+// selectHow is what moving the cursor does to the selection
+type selectHow int
+
+const (
+	selectOnly      selectHow = iota // the cursor row becomes the selection
+	selectToggle                     // the cursor row is added or removed
+	selectRange                      // the rows from the anchor to the cursor become the selection
+	selectAddRange                   // the rows from the anchor to the cursor are added
+	selectUnchanged                  // only the cursor moves
+)
+
+// WithSelectionMode sets how rows are selected, multiple rows unless changed
+func (gt *GenericTable[T]) WithSelectionMode(mode SelectionMode) *GenericTable[T] {
+	gt.SetSelectionMode(mode)
+	return gt
+}
+
+// SetSelectionMode changes how rows are selected, trimming the selection to fit
+func (gt *GenericTable[T]) SetSelectionMode(mode SelectionMode) {
+
+	gt.mu.Lock()
+	gt.selectionMode = mode
+	gt.mu.Unlock()
+
+	gt.SetSelectedItems(gt.SelectedItems())
+}
+
+func (gt *GenericTable[T]) SelectionMode() SelectionMode {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.selectionMode
+}
+
+// limitSelectionLocked keeps only what the selection mode allows of the items
+func (gt *GenericTable[T]) limitSelectionLocked(items []*T) []*T {
+
+	switch {
+	case gt.selectionMode == SelectNone:
+		return nil
+	case gt.selectionMode == SelectSingle && len(items) > 1:
+		return items[:1]
+	}
+	return items
+}
+
+// moveTo puts the cursor on the cell, scrolling it into view, and updates the selection
+func (gt *GenericTable[T]) moveTo(id widget.TableCellID, how selectHow) {
+
+	gt.moveHow = how
+	gt.table.UnselectAll() // the table ignores selecting the cell it has selected already
+	gt.table.Select(id)
+	gt.moveHow = selectOnly
+}
+
+// cellSelected follows the table's cell selection, which moveTo and clicks drive
+func (gt *GenericTable[T]) cellSelected(id widget.TableCellID) {
+
+	gt.cursor = id
+	item := gt.rowAt(id.Row)
+
+	gt.mu.Lock()
+	how := gt.moveHow
+	switch gt.selectionMode {
+	case SelectNone:
+		how = selectUnchanged
+	case SelectSingle:
+		if how != selectUnchanged && how != selectToggle {
+			how = selectOnly
+		}
+	}
+
+	switch {
+	case how == selectUnchanged:
+		gt.mu.Unlock()
+		return
+	case how == selectOnly && item != nil:
+		gt.selection.RemoveAll()
+		gt.selection.Add(item)
+	case how == selectToggle && item != nil:
+		if gt.selection.Contains(item) {
+			gt.selection.Remove(item)
+		} else {
+			if gt.selectionMode == SelectSingle {
+				gt.selection.RemoveAll()
+			}
+			gt.selection.Add(item)
+		}
+	case how == selectRange || how == selectAddRange:
+		if how == selectRange {
+			gt.selection.RemoveAll()
+		}
+		for _, rangeItem := range gt.rangeLocked(gt.anchor, id.Row) {
+			gt.selection.Add(rangeItem)
+		}
+	}
+	if how == selectOnly || how == selectToggle {
+		gt.anchor = id.Row
+	}
+	gt.mu.Unlock()
+
+	gt.selectionChanged()
+}
+
+// rangeLocked returns the items of the rows between the two, only the loaded ones when paged
+func (gt *GenericTable[T]) rangeLocked(from, to int) []*T {
+
+	if from > to {
+		from, to = to, from
+	}
+
+	var items []*T
+	for row := max(from, 0); row <= to; row++ {
+		var item *T
+		if gt.pager != nil {
+			item = gt.pager.peek(row)
+		} else if row < len(gt.rows) {
+			item = gt.rows[row]
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// cellTapped moves the cursor to the cell, ctrl-click toggling its row and shift-click selecting a range
+func (gt *GenericTable[T]) cellTapped(cell *TableCell) {
+
+	modifiers := currentModifiers()
+	shift := modifiers&fyne.KeyModifierShift != 0
+	ctrl := modifiers&fyne.KeyModifierShortcutDefault != 0
+
+	switch {
+	case shift && ctrl:
+		gt.moveTo(cell.id, selectAddRange)
+	case shift:
+		gt.moveTo(cell.id, selectRange)
+	case ctrl:
+		gt.moveTo(cell.id, selectToggle)
+	default:
+		gt.moveTo(cell.id, selectOnly)
+	}
+
+	if c := fyne.CurrentApp().Driver().CanvasForObject(gt.table); c != nil {
+		c.Focus(gt.table)
+	}
+}
+
+// typedKey moves the cursor like a spreadsheet does, shift extending the selection and ctrl leaving it alone.
+// Space toggles the cursor row and Enter starts editing, the key handler gets the other keys.
+func (gt *GenericTable[T]) typedKey(event *fyne.KeyEvent) bool {
+
+	rows, cols := gt.rowCount(), gt.shownCount()
+	if rows == 0 || cols == 0 {
+		return gt.keyHandler != nil && gt.keyHandler(event)
+	}
+
+	modifiers := currentModifiers()
+	shift := modifiers&fyne.KeyModifierShift != 0
+	ctrl := modifiers&fyne.KeyModifierShortcutDefault != 0
+
+	how := selectOnly
+	switch {
+	case shift && ctrl:
+		how = selectAddRange
+	case shift:
+		how = selectRange
+	case ctrl:
+		how = selectUnchanged
+	}
+
+	next := gt.cursor
+	switch event.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		gt.editCell(gt.cursor)
+		return true
+	case fyne.KeySpace:
+		if shift {
+			gt.moveTo(next, how)
+		} else {
+			gt.moveTo(next, selectToggle)
+		}
+		return true
+	case fyne.KeyUp:
+		next.Row--
+	case fyne.KeyDown:
+		next.Row++
+	case fyne.KeyPageUp:
+		next.Row -= gt.pageRows()
+	case fyne.KeyPageDown:
+		next.Row += gt.pageRows()
+	case fyne.KeyHome:
+		next.Row = 0
+	case fyne.KeyEnd:
+		next.Row = rows - 1
+	case fyne.KeyLeft:
+		next.Col--
+		how = selectUnchanged
+	case fyne.KeyRight:
+		next.Col++
+		how = selectUnchanged
+	default:
+		return gt.keyHandler != nil && gt.keyHandler(event)
+	}
+
+	next.Row = max(0, min(next.Row, rows-1))
+	next.Col = max(0, min(next.Col, cols-1))
+	gt.moveTo(next, how)
+	return true
+}
+
+// pageRows is the number of rows that fit in the table
+func (gt *GenericTable[T]) pageRows() int {
+
+	rowHeight := max(minCellSize, gt.rowHeight) + theme.Padding()
+	return max(int(gt.table.Size().Height/rowHeight)-1, 1)
+}
+
+func currentModifiers() fyne.KeyModifier {
+
+	if drv, ok := fyne.CurrentApp().Driver().(desktop.Driver); ok {
+		return drv.CurrentKeyModifiers()
+	}
+	return 0
+}
@@ -0,0 +1,286 @@
+package table
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// pager loads the rows of a source a page at a time in the background, keeping the most recently used pages
+type pager[T any] struct {
+	mu       sync.Mutex
+	source   DataSource[T]
+	pageSize int
+	maxPages int
+	count    int
+	pages    map[int][]*T
+	recent   []int // page numbers, the most recently used last
+	loading  map[int]bool
+	failed   map[int]bool // pages not fetched again until the next refresh
+	gen      int          // bumped whenever the cached rows go stale, so late results are dropped
+	shown    func() int   // the number of rows on screen, whose pages are always kept
+	onLoaded func()
+	onError  func(error)
+}
+
+func newPager[T any](source DataSource[T], pageSize, maxPages int, shown func() int, onLoaded func(), onError func(error)) *pager[T] {
+
+	return &pager[T]{
+		source:   source,
+		pageSize: max(pageSize, 1),
+		maxPages: max(maxPages, 2),
+		pages:    map[int][]*T{},
+		loading:  map[int]bool{},
+		failed:   map[int]bool{},
+		shown:    shown,
+		onLoaded: onLoaded,
+		onError:  onError,
+	}
+}
+
+// rowAt returns the item of the row, or nil while its page is being loaded
+func (p *pager[T]) rowAt(row int) *T {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if row < 0 || row >= p.count {
+		return nil
+	}
+
+	page := row / p.pageSize
+	items, ok := p.pages[page]
+	if !ok {
+		p.load(page)
+		return nil
+	}
+	p.touch(page)
+
+	if offset := row % p.pageSize; offset < len(items) {
+		return items[offset]
+	}
+	return nil
+}
+
+// peek returns the item of the row if its page is loaded, without loading it
+func (p *pager[T]) peek(row int) *T {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if items, ok := p.pages[row/p.pageSize]; ok && row >= 0 && row%p.pageSize < len(items) {
+		return items[row%p.pageSize]
+	}
+	return nil
+}
+
+func (p *pager[T]) touch(page int) {
+
+	if len(p.recent) > 0 && p.recent[len(p.recent)-1] == page {
+		return
+	}
+	if i := slices.Index(p.recent, page); i >= 0 {
+		p.recent = slices.Delete(p.recent, i, i+1)
+	}
+	p.recent = append(p.recent, page)
+}
+
+// load fetches the page off the UI goroutine
+func (p *pager[T]) load(page int) {
+
+	if p.loading[page] || p.failed[page] {
+		return
+	}
+	p.loading[page] = true
+
+	gen, keep := p.gen, p.keptPages()
+	go func() {
+		items, err := p.source.Fetch(page*p.pageSize, p.pageSize)
+
+		p.mu.Lock()
+		if gen != p.gen {
+			p.mu.Unlock()
+			return
+		}
+		delete(p.loading, page)
+		if err == nil {
+			p.pages[page] = items
+			p.touch(page)
+			for len(p.recent) > keep {
+				delete(p.pages, p.recent[0])
+				p.recent = p.recent[1:]
+			}
+		} else {
+			p.failed[page] = true
+		}
+		p.mu.Unlock()
+
+		if err != nil {
+			p.onError(fmt.Errorf("Loading rows %d to %d failed: %w", page*p.pageSize+1, (page+1)*p.pageSize, err))
+			return
+		}
+		p.onLoaded()
+	}()
+}
+
+// keptPages is the number of pages kept, never fewer than the rows on screen can span
+// so that loading one of them doesn't evict another
+func (p *pager[T]) keptPages() int {
+
+	if p.shown == nil {
+		return p.maxPages
+	}
+	return max(p.maxPages, (p.shown()+p.pageSize-1)/p.pageSize+1)
+}
+
+// reset drops the cached pages and counts the rows again
+func (p *pager[T]) reset() {
+	p.invalidateFrom(0)
+}
+
+// invalidateFrom drops the cached pages holding the row or later ones, which may have changed or moved,
+// and counts the rows again
+func (p *pager[T]) invalidateFrom(row int) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	first := max(row, 0) / p.pageSize
+	p.gen++
+	maps.DeleteFunc(p.pages, func(page int, _ []*T) bool { return page >= first })
+	p.recent = slices.DeleteFunc(p.recent, func(page int) bool { return page >= first })
+	p.loading = map[int]bool{} // late results are dropped, the pages are loaded again when shown
+	p.failed = map[int]bool{}
+
+	gen := p.gen
+	go func() {
+		count, err := p.source.Count()
+
+		p.mu.Lock()
+		current := gen == p.gen
+		if current && err == nil {
+			p.count = count
+		}
+		p.mu.Unlock()
+
+		switch {
+		case !current:
+		case err != nil:
+			p.onError(err)
+		default:
+			p.onLoaded()
+		}
+	}()
+}
+
+// replace swaps an updated item into the cached pages, false if it isn't cached
+func (p *pager[T]) replace(old, item *T) bool {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, items := range p.pages {
+		if i := slices.Index(items, old); i >= 0 {
+			items[i] = item
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pager[T]) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.count
+}
+
+// rowOf returns the row of a cached item, -1 if it isn't cached
+func (p *pager[T]) rowOf(item *T) int {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for page, items := range p.pages {
+		if i := slices.Index(items, item); i >= 0 {
+			return page*p.pageSize + i
+		}
+	}
+	return -1
+}
+
+// cached returns the loaded rows in row order
+func (p *pager[T]) cached() []*T {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pages := make([]int, 0, len(p.pages))
+	for page := range p.pages {
+		pages = append(pages, page)
+	}
+	slices.Sort(pages)
+
+	var items []*T
+	for _, page := range pages {
+		items = append(items, p.pages[page]...)
+	}
+	return items
+}
+
+// ==================== table =======================
+
+// SetPagedDataSource backs the table with a source too large to load at once. Rows are fetched a page at a time
+// as they scroll into view, keeping at most maxPages pages or the ones on screen if more, and show as placeholders
+// until they arrive. A page that fails to load is tried again on the next refresh.
+// Sorting and filtering are only available if the source does them.
+func (gt *GenericTable[T]) SetPagedDataSource(source DataSource[T], pageSize, maxPages int) {
+	gt.setSource(source, newPager(source, pageSize, maxPages, gt.shownRows, gt.redraw, gt.reportError))
+}
+
+// IsPaged tells whether the rows are loaded a page at a time
+func (gt *GenericTable[T]) IsPaged() bool {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return gt.pager != nil
+}
+
+// rowCount is the number of rows shown
+func (gt *GenericTable[T]) rowCount() int {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	if gt.pager != nil {
+		return gt.pager.size()
+	}
+	return len(gt.rows)
+}
+
+// rowAt returns the item shown in the row, nil if there is none or it is still loading
+func (gt *GenericTable[T]) rowAt(row int) *T {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	if gt.pager != nil {
+		return gt.pager.rowAt(row)
+	}
+	if row < 0 || row >= len(gt.rows) {
+		return nil
+	}
+	return gt.rows[row]
+}
+
+// shownRows is the most rows on screen at once, counting the ones partly scrolled into view
+func (gt *GenericTable[T]) shownRows() int {
+	return gt.pageRows() + 3
+}
+
+// loadedRowsLocked returns the rows available without fetching, all of them unless paged
+func (gt *GenericTable[T]) loadedRowsLocked() []*T {
+	if gt.pager != nil {
+		return gt.pager.cached()
+	}
+	return gt.rows
+}
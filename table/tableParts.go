@@ -5,25 +5,47 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
 type TableCell struct {
 	widget.BaseWidget
-	bg    *canvas.Rectangle
-	shape *canvas.Circle
-	label *widget.Label
+	bg      *canvas.Rectangle
+	mark    *canvas.Rectangle // flags a changed value along the leading edge
+	text    *canvas.Text
+	strike  *canvas.Line
+	content fyne.CanvasObject  // drawn by the renderer of the column in place of the text, nil if there is none
+	id      widget.TableCellID // the cell currently shown, cells are recycled while scrolling
+	handler cellHandler
+
+	renderer  any // the CellRenderer the content came from
+	minHeight float32
+}
+
+// cellHandler receives the pointer events of the cells, which would otherwise go to the table
+type cellHandler interface {
+	cellTapped(cell *TableCell)
+	cellDoubleTapped(cell *TableCell)
+	cellTappedSecondary(cell *TableCell, pos fyne.Position)
 }
 
 func NewTableCell() *TableCell {
 	bg := canvas.NewRectangle(color.Transparent)
-	circle := canvas.NewCircle(color.NRGBA{R: 0, G: 150, B: 255, A: 255})
-	label := widget.NewLabel("..")
+	text := canvas.NewText("..", theme.Color(theme.ColorNameForeground))
+
+	mark := canvas.NewRectangle(color.Transparent)
+	mark.Hide()
+
+	strike := canvas.NewLine(color.Transparent)
+	strike.StrokeWidth = 1
+	strike.Hide()
 
 	c := &TableCell{
-		bg:    bg,
-		shape: circle,
-		label: label,
+		bg:     bg,
+		mark:   mark,
+		text:   text,
+		strike: strike,
 	}
 	c.ExtendBaseWidget(c)
 	return c
@@ -31,34 +53,112 @@ func NewTableCell() *TableCell {
 
 func (tc *TableCell) CreateRenderer() fyne.WidgetRenderer {
 
-	objects := []fyne.CanvasObject{tc.bg, tc.shape, tc.label}
-	return &tableCellRenderer{
-		cell:    tc,
-		objects: objects,
+	return &tableCellRenderer{cell: tc}
+}
+
+// setText shows the text in the style, the foreground of the theme unless the style sets one
+func (tc *TableCell) setText(text string, alignment fyne.TextAlign, style CellStyle) {
+
+	tc.text.Text = text
+	tc.text.Alignment = alignment
+	tc.text.TextSize = theme.TextSize()
+	tc.text.TextStyle = fyne.TextStyle{Bold: style.Bold, Italic: style.Italic}
+	tc.text.Color = style.Foreground
+	if tc.text.Color == nil {
+		tc.text.Color = theme.Color(theme.ColorNameForeground)
 	}
+	tc.strike.StrokeColor = tc.text.Color
+	tc.strike.Hidden = !style.StrikeThrough
+
+	tc.layoutText(tc.Size())
+	tc.text.Refresh()
+	tc.strike.Refresh()
 }
 
+// layoutText places the text inside the padding and the strike-through line across it
+func (tc *TableCell) layoutText(size fyne.Size) {
+
+	padding := theme.InnerPadding()
+	textSize := tc.text.MinSize()
+	y := (size.Height - textSize.Height) / 2
+	tc.text.Resize(fyne.NewSize(max(size.Width-2*padding, 0), textSize.Height))
+	tc.text.Move(fyne.NewPos(padding, y))
+
+	width := min(textSize.Width, size.Width-2*padding)
+	x := padding
+	switch tc.text.Alignment {
+	case fyne.TextAlignCenter:
+		x = (size.Width - width) / 2
+	case fyne.TextAlignTrailing:
+		x = size.Width - padding - width
+	}
+	tc.strike.Position1 = fyne.NewPos(x, y+textSize.Height/2)
+	tc.strike.Position2 = fyne.NewPos(x+width, y+textSize.Height/2)
+}
+
+// setContent shows the object in place of the text, nil shows the text again
+func (tc *TableCell) setContent(obj fyne.CanvasObject) {
+
+	tc.content = obj
+	if obj == nil {
+		tc.text.Show()
+		return
+	}
+	tc.text.Hide()
+	tc.strike.Hide()
+	obj.Resize(tc.Size())
+	obj.Move(fyne.NewPos(0, 0))
+	obj.Show()
+}
+
+// setMark shows the mark in the colour, or hides it for nil
+func (tc *TableCell) setMark(clr color.Color) {
+
+	if clr == nil {
+		tc.mark.Hide()
+		return
+	}
+	tc.mark.FillColor = clr
+	tc.mark.Show()
+	tc.mark.Refresh()
+}
+
+// minCellSize is the least a cell takes either way
+const minCellSize = 30
+
 func (tc *TableCell) MinSize() fyne.Size {
-	return fyne.NewSize(30, 30)
+	return fyne.NewSize(minCellSize, max(minCellSize, tc.minHeight))
+}
+
+func (tc *TableCell) Tapped(*fyne.PointEvent) {
+	if tc.handler != nil {
+		tc.handler.cellTapped(tc)
+	}
+}
+
+func (tc *TableCell) DoubleTapped(*fyne.PointEvent) {
+	if tc.handler != nil {
+		tc.handler.cellDoubleTapped(tc)
+	}
+}
+
+func (tc *TableCell) TappedSecondary(event *fyne.PointEvent) {
+	if tc.handler != nil {
+		tc.handler.cellTappedSecondary(tc, event.AbsolutePosition)
+	}
 }
 
 type tableCellRenderer struct {
-	cell    *TableCell
-	objects []fyne.CanvasObject
+	cell *TableCell
 }
 
 func (tcr *tableCellRenderer) Layout(size fyne.Size) {
 	tcr.cell.bg.Resize(size)
-
-	// 40% of smaller dimension
-	diameter := fyne.Min(size.Width, size.Height) * 0.4
-
-	tcr.cell.shape.Resize(fyne.NewSize(diameter, diameter))
-	tcr.cell.shape.Move(fyne.NewPos(
-		//	(size.Width-diameter)/2,	// centered
-		diameter,
-		(size.Height-diameter)/2,
-	))
+	tcr.cell.mark.Resize(fyne.NewSize(3, size.Height))
+	if tcr.cell.content != nil {
+		tcr.cell.content.Resize(size)
+	}
+	tcr.cell.layoutText(size)
 }
 
 func (tcr *tableCellRenderer) MinSize() fyne.Size {
@@ -72,14 +172,25 @@ func (tcr *tableCellRenderer) Refresh() {
 func (tcr *tableCellRenderer) Destroy() {}
 
 func (tcr *tableCellRenderer) Objects() []fyne.CanvasObject {
-	return tcr.objects
+
+	cell := tcr.cell
+	if cell.content != nil {
+		return []fyne.CanvasObject{cell.bg, cell.content, cell.mark}
+	}
+	return []fyne.CanvasObject{cell.bg, cell.mark, cell.text, cell.strike}
 }
 
 // ========================== Table Header =========================
 
 type HeaderLabel struct {
 	widget.Label
-	onTapped func()
+	onTapped  func()
+	onMenu    func(pos fyne.Position)
+	onDragged func(event *fyne.DragEvent)
+	onDragEnd func()
+	onResized func(width float32)
+	dragging  bool
+	dragX     float32 // where the pointer is while dragging, relative to the header
 }
 
 func NewHeaderLabel(text string, tapped func()) *HeaderLabel {
@@ -98,6 +209,73 @@ func (h *HeaderLabel) Tapped(*fyne.PointEvent) {
 	}
 }
 
+func (h *HeaderLabel) TappedSecondary(event *fyne.PointEvent) {
+	if h.onMenu != nil {
+		h.onMenu(event.AbsolutePosition)
+	}
+}
+
+func (h *HeaderLabel) Dragged(event *fyne.DragEvent) {
+	if h.onDragged != nil {
+		h.onDragged(event)
+	}
+}
+
+func (h *HeaderLabel) DragEnd() {
+	if h.onDragEnd != nil {
+		h.onDragEnd()
+	}
+}
+
+// Resize tells about the widths the table gives the header, which follow the column
+func (h *HeaderLabel) Resize(size fyne.Size) {
+	h.Label.Resize(size)
+	if h.onResized != nil {
+		h.onResized(size.Width)
+	}
+}
+
+// ========================== Table =========================
+
+// navTable extends widget.Table so keys can be handled before its default navigation
+type navTable struct {
+	widget.Table
+	onTypedKey func(*fyne.KeyEvent) bool // true if the key was consumed
+	onResized  func()                    // after the user dragged a column wider or narrower
+	resizing   bool
+}
+
+func newNavTable(length func() (int, int), create func() fyne.CanvasObject, update func(widget.TableCellID, fyne.CanvasObject)) *navTable {
+
+	t := &navTable{}
+	t.Length = length
+	t.CreateCell = create
+	t.UpdateCell = update
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+func (t *navTable) TypedKey(event *fyne.KeyEvent) {
+	if t.onTypedKey != nil && t.onTypedKey(event) {
+		return
+	}
+	t.Table.TypedKey(event)
+}
+
+// Dragged resizes a column when dragged between the headers, drags elsewhere do nothing
+func (t *navTable) Dragged(event *fyne.DragEvent) {
+	t.resizing = true
+	t.Table.Dragged(event)
+}
+
+func (t *navTable) DragEnd() {
+	t.Table.DragEnd()
+	if t.resizing && t.onResized != nil {
+		t.onResized()
+	}
+	t.resizing = false
+}
+
 // ================= integer set ================
 
 type IntSet map[int]struct{}
@@ -131,3 +309,70 @@ func (set IntSet) Contains(v int) bool {
 	_, ok := set[v]
 	return ok
 }
+
+// ================= item set ================
+
+// itemSet holds items keyed by identity; the pointer itself unless a key function is supplied
+type itemSet[T any] struct {
+	keyFunc func(*T) any
+	items   map[any]*T
+}
+
+func newItemSet[T any](keyFunc func(*T) any) *itemSet[T] {
+
+	return &itemSet[T]{
+		keyFunc: keyFunc,
+		items:   map[any]*T{},
+	}
+}
+
+func (set *itemSet[T]) keyOf(item *T) any {
+	if set.keyFunc == nil {
+		return item
+	}
+	return set.keyFunc(item)
+}
+
+func (set *itemSet[T]) size() int {
+	return len(set.items)
+}
+
+func (set *itemSet[T]) Add(item *T) {
+	set.items[set.keyOf(item)] = item
+}
+
+func (set *itemSet[T]) Remove(item *T) {
+	delete(set.items, set.keyOf(item))
+}
+
+func (set *itemSet[T]) RemoveAll() {
+	clear(set.items)
+}
+
+func (set *itemSet[T]) Contains(item *T) bool {
+	_, ok := set.items[set.keyOf(item)]
+	return ok
+}
+
+// members returns the items in no particular order
+func (set *itemSet[T]) members() []*T {
+
+	members := make([]*T, 0, len(set.items))
+	for _, item := range set.items {
+		members = append(members, item)
+	}
+	return members
+}
+
+// retainOnly keeps the members still present in the data, re-pointing them at the current instances
+func (set *itemSet[T]) retainOnly(data []*T) {
+
+	kept := make(map[any]*T, len(set.items))
+	for _, item := range data {
+		key := set.keyOf(item)
+		if _, ok := set.items[key]; ok {
+			kept[key] = item
+		}
+	}
+	set.items = kept
+}
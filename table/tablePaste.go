@@ -0,0 +1,281 @@
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// PastedRow is an item built from one line of pasted text, Err is set if it couldn't be parsed or failed validation
+type PastedRow[T any] struct {
+	Line   int
+	Values []string
+	Item   T
+	Target *T // the item it replaces, nil when it is added
+	Err    error
+}
+
+// ParseRecords splits TSV or CSV text into records, tabs taking priority as that is what spreadsheets copy
+func ParseRecords(text string) ([][]string, error) {
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = separatorOf(text)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// separatorOf picks a tab if the first record has one outside quotes, otherwise a comma
+func separatorOf(text string) rune {
+
+	quoted := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '\t':
+			return '\t'
+		case r == '\n':
+			return ','
+		}
+	}
+	return ','
+}
+
+// columnMapping matches the records to the columns, by label if the first record is a header
+// otherwise by position in the order they are copied. Returns the column index per value and whether
+// the first record was a header.
+func (gt *GenericTable[T]) columnMapping(first []string) ([]int, bool) {
+
+	mapping := make([]int, len(first))
+	matched := 0
+	for i, value := range first {
+		mapping[i] = -1
+		for c, col := range gt.columns {
+			if strings.EqualFold(strings.TrimSpace(value), col.field.Label) {
+				mapping[i] = c
+				matched++
+				break
+			}
+		}
+	}
+	if matched > 0 && matched == len(first) {
+		return mapping, true
+	}
+
+	order := gt.exportOrder()
+	for i := range mapping {
+		mapping[i] = -1
+		if i < len(order) {
+			mapping[i] = order[i]
+		}
+	}
+	return mapping, false
+}
+
+// ItemFromRecord applies the values to a copy of the base item through the column setters, then validates it.
+// Values for read-only columns are ignored.
+func (gt *GenericTable[T]) ItemFromRecord(base T, record []string, mapping []int) (T, error) {
+
+	item := base
+	for i, value := range record {
+		if i >= len(mapping) || mapping[i] < 0 || mapping[i] >= len(gt.columns) {
+			continue
+		}
+		col := gt.columns[mapping[i]]
+		if col.IsIcon() || !col.field.IsEditable() {
+			continue
+		}
+		if err := col.field.SetFromString(&item, value); err != nil {
+			return item, err
+		}
+	}
+	return item, gt.Validate(item)
+}
+
+// ParseRows builds new items from the pasted text
+func (gt *GenericTable[T]) ParseRows(text string) ([]PastedRow[T], []int, error) {
+	return gt.ParseRowsOnto(text, nil)
+}
+
+// ParseRowsOnto builds the first rows onto copies of the targets, so the fields not pasted keep their values,
+// and the rest as new items. Each is validated as it would be stored.
+func (gt *GenericTable[T]) ParseRowsOnto(text string, targets []*T) ([]PastedRow[T], []int, error) {
+
+	records, err := ParseRecords(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("Nothing to paste")
+	}
+
+	mapping, hasHeader := gt.columnMapping(records[0])
+	line := 1
+	if hasHeader {
+		records = records[1:]
+		line++
+	}
+
+	rows := make([]PastedRow[T], len(records))
+	for i, record := range records {
+		base := gt.newItemFunc()
+		var target *T
+		if i < len(targets) {
+			target = targets[i]
+			base = *target
+		}
+		item, err := gt.ItemFromRecord(base, record, mapping)
+		rows[i] = PastedRow[T]{Line: line + i, Values: record, Item: item, Target: target, Err: err}
+	}
+	return rows, mapping, nil
+}
+
+// ==================== paste dialog =======================
+
+// PasteFromClipboard parses the clipboard into items and previews them before appending them,
+// or replacing the selected items
+func (tc *TableContainer[T]) PasteFromClipboard(app fyne.App) {
+
+	if tc.IsBusy() {
+		return
+	}
+
+	text := app.Clipboard().Content()
+	rows, _, err := tc.table.ParseRows(text)
+	if err != nil {
+		dialog.ShowError(err, tc.window)
+		return
+	}
+	var replaceRows []PastedRow[T]
+	if selected := tc.table.SelectedItems(); len(selected) > 0 {
+		replaceRows, _, _ = tc.table.ParseRowsOnto(text, selected)
+	}
+
+	tc.showRowsPreview("Paste", rows, replaceRows, func(rows []PastedRow[T]) {
+		tc.pasteRows(rows)
+	})
+}
+
+// showRowsPreview lists the rows with their errors and lets the user apply the valid ones.
+// With replaceRows the user can choose those instead, built onto the selected items.
+func (tc *TableContainer[T]) showRowsPreview(title string, rows, replaceRows []PastedRow[T], apply func([]PastedRow[T])) {
+
+	shown := rows
+	preview := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(widget.NewIcon(theme.ConfirmIcon()), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row := shown[id]
+			box := obj.(*fyne.Container)
+			icon, label := box.Objects[0].(*widget.Icon), box.Objects[1].(*widget.Label)
+
+			text := fmt.Sprintf("%d: %s", row.Line, strings.Join(row.Values, " | "))
+			if row.Target != nil {
+				text = "→ " + text
+			}
+			if row.Err != nil {
+				icon.SetResource(theme.ErrorIcon())
+				label.SetText(text + "  (" + row.Err.Error() + ")")
+			} else {
+				icon.SetResource(theme.ConfirmIcon())
+				label.SetText(text)
+			}
+		},
+	)
+
+	summary := widget.NewLabel("")
+	var previewDialog *dialog.CustomDialog
+
+	applyButton := widget.NewButton(title, func() {
+		previewDialog.Hide()
+		apply(shown)
+	})
+	applyButton.Importance = widget.HighImportance
+
+	show := func(next []PastedRow[T]) {
+		shown = next
+		valid, replaced := 0, 0
+		for _, row := range shown {
+			if row.Err == nil {
+				valid++
+				if row.Target != nil {
+					replaced++
+				}
+			}
+		}
+		text := fmt.Sprintf("%d of %d rows can be added", valid, len(shown))
+		if replaced > 0 {
+			text = fmt.Sprintf("%d of %d rows can be pasted, %d replacing selected items", valid, len(shown), replaced)
+		}
+		summary.SetText(text)
+		if valid == 0 {
+			applyButton.Disable()
+		} else {
+			applyButton.Enable()
+		}
+		preview.Refresh()
+	}
+	show(rows)
+
+	var top fyne.CanvasObject = summary
+	if replaceRows != nil {
+		mode := widget.NewRadioGroup([]string{"Append", "Replace selected"}, func(choice string) {
+			if choice == "Replace selected" {
+				show(replaceRows)
+			} else {
+				show(rows)
+			}
+		})
+		mode.Horizontal = true
+		mode.Required = true
+		mode.SetSelected("Append")
+		top = container.NewVBox(mode, summary)
+	}
+
+	previewDialog = dialog.NewCustomWithoutButtons(title, container.NewBorder(top, nil, nil, nil, preview), tc.window)
+	previewDialog.SetButtons([]fyne.CanvasObject{widget.NewButton("Cancel", previewDialog.Hide), applyButton})
+	previewDialog.Resize(fyne.NewSize(500, 300))
+	previewDialog.Show()
+}
+
+// pasteRows adds the valid rows and stores those built onto selected items in their place,
+// rows that failed leave their targets as they are
+func (tc *TableContainer[T]) pasteRows(rows []PastedRow[T]) {
+
+	var failed error
+	tc.table.inHistoryGroup("Paste", func() error {
+		for _, row := range rows {
+			if row.Err != nil {
+				continue
+			}
+			item := row.Item
+			var err error
+			if row.Target != nil {
+				err = tc.table.ReplaceItem(row.Target, &item)
+			} else {
+				err = tc.table.InsertItem(&item)
+			}
+			if err != nil && failed == nil {
+				failed = fmt.Errorf("Line %d: %w", row.Line, err)
+			}
+		}
+		return nil
+	})
+	tc.showError(failed)
+	tc.updateEditButtons()
+}
@@ -0,0 +1,73 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+type pasteItem struct {
+	Name string `meta:"editable"`
+	City string `meta:"editable"`
+	Age  int    `meta:"editable"`
+}
+
+func newPasteTable(t *testing.T, items []*pasteItem) *GenericTable[pasteItem] {
+
+	test.NewApp()
+	columns, err := ColumnsFor[pasteItem]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gt := NewGenericTable(columns, func() pasteItem { return pasteItem{} })
+	gt.SetData(items)
+	return gt
+}
+
+func TestPasteAfterMoveColumn(t *testing.T) {
+
+	original := []pasteItem{{"Alice", "Bern", 30}, {"Bob", "Oslo", 25}}
+	gt := newPasteTable(t, []*pasteItem{&original[0], &original[1]})
+	gt.MoveColumn(2, 0)
+	gt.ShowColumn(1, false)
+
+	text, err := gt.ExportCSV(TSVOptions(VisibleRows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, _, err := gt.ParseRows(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(original) {
+		t.Fatalf("got %d rows from %q, want %d", len(rows), text, len(original))
+	}
+	for i, row := range rows {
+		if row.Err != nil {
+			t.Errorf("line %d: %v", row.Line, row.Err)
+		}
+		if row.Item != original[i] {
+			t.Errorf("line %d: got %+v, want %+v", row.Line, row.Item, original[i])
+		}
+	}
+}
+
+func TestParseRecordsSeparator(t *testing.T) {
+
+	for text, want := range map[string][][]string{
+		"a\tb\n\"c,d\"\te\n":  {{"a", "b"}, {"c,d", "e"}},
+		"a,\"b\tc\"\nd,e\n":   {{"a", "b\tc"}, {"d", "e"}},
+		"a,b\n\"c\td\",e\n":   {{"a", "b"}, {"c\td", "e"}},
+		"\"x\ty\",\"z\"\n1,2": {{"x\ty", "z"}, {"1", "2"}},
+	} {
+		records, err := ParseRecords(text)
+		if err != nil {
+			t.Errorf("%q: %v", text, err)
+			continue
+		}
+		if !reflect.DeepEqual(records, want) {
+			t.Errorf("%q: got %q, want %q", text, records, want)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package table
+
+import (
+	"image/color"
+	"net/url"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CellRenderer draws the values of a column in place of their text.
+// The table pools the objects a renderer creates and reuses them for any row, so Update has to set
+// everything that depends on the item. Renderers are compared to find their pool, use pointers.
+type CellRenderer[T any] interface {
+	Create() fyne.CanvasObject
+	Update(obj fyne.CanvasObject, cell RenderedCell[T])
+}
+
+// RenderedCell is the cell a renderer updates
+type RenderedCell[T any] struct {
+	Item      *T
+	Text      string // the value of the column as text
+	Alignment fyne.TextAlign
+	Selected  bool
+	Edit      func(change func(*T)) // changes the item like an inline edit would, failures go to the error handler
+}
+
+// WithRenderer draws the column with the renderer rather than as text
+func (col Column[T]) WithRenderer(renderer CellRenderer[T]) Column[T] {
+	col.renderer = renderer
+	return col
+}
+
+// HasRenderer tells whether the column is drawn by a CellRenderer
+func (col *Column[T]) HasRenderer() bool {
+	return col.renderer != nil
+}
+
+// WithRowHeight makes the rows taller, for wrapped text and the like
+func (gt *GenericTable[T]) WithRowHeight(height float32) *GenericTable[T] {
+	gt.rowHeight = height
+	gt.redraw()
+	return gt
+}
+
+// newCell creates a cell of the table, high enough for the row height
+func (gt *GenericTable[T]) newCell() *TableCell {
+	cell := NewTableCell()
+	cell.handler = gt
+	cell.minHeight = gt.rowHeight
+	return cell
+}
+
+// renderCell shows the item in the cell with the renderer of the column, swapping the pooled objects as needed
+func (gt *GenericTable[T]) renderCell(cell *TableCell, column *Column[T], item *T, selected bool) {
+
+	var renderer CellRenderer[T]
+	if column != nil && item != nil {
+		renderer = column.renderer
+	}
+
+	if cell.renderer != any(renderer) {
+		if previous, ok := cell.renderer.(CellRenderer[T]); ok {
+			gt.pools[previous] = append(gt.pools[previous], cell.content)
+		}
+		cell.renderer = nil
+		cell.setContent(nil)
+		if renderer != nil {
+			cell.renderer = renderer
+			cell.setContent(gt.pooledObject(renderer))
+		}
+		cell.Refresh()
+	}
+	if renderer == nil {
+		return
+	}
+
+	renderer.Update(cell.content, RenderedCell[T]{
+		Item:      item,
+		Text:      column.StringValueFor(*item),
+		Alignment: column.alignment,
+		Selected:  selected,
+		Edit:      func(change func(*T)) { gt.applyChange(item, change) },
+	})
+}
+
+func (gt *GenericTable[T]) pooledObject(renderer CellRenderer[T]) fyne.CanvasObject {
+
+	pool := gt.pools[renderer]
+	if len(pool) == 0 {
+		return renderer.Create()
+	}
+	obj := pool[len(pool)-1]
+	gt.pools[renderer] = pool[:len(pool)-1]
+	return obj
+}
+
+// applyChange replaces the item by a changed copy, validated by the field validators and then the item validator
+// like an inline edit. The change may touch any field, so all of them are checked.
+func (gt *GenericTable[T]) applyChange(item *T, change func(*T)) {
+
+	if gt.isBusy() {
+		gt.reportError(errBusy)
+		gt.redraw() // back to the unchanged value
+		return
+	}
+
+	edited := *item
+	change(&edited)
+
+	err := gt.Validate(edited)
+	if err == nil {
+		err = gt.ReplaceItem(item, &edited)
+	}
+	if err != nil {
+		gt.reportError(err)
+		gt.redraw() // back to the unchanged value
+	}
+}
+
+// ==================== built in renderers =======================
+
+type checkCellRenderer[T any] struct {
+	value func(T) bool
+	set   func(*T, bool)
+}
+
+// NewCheckRenderer shows a check box, which can be toggled if set isn't nil
+func NewCheckRenderer[T any](value func(T) bool, set func(*T, bool)) CellRenderer[T] {
+	return &checkCellRenderer[T]{value: value, set: set}
+}
+
+func (r *checkCellRenderer[T]) Create() fyne.CanvasObject {
+	return widget.NewCheck("", nil)
+}
+
+func (r *checkCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+
+	check := obj.(*widget.Check)
+	check.OnChanged = nil // setting the value would report it as changed
+	check.SetChecked(r.value(*cell.Item))
+	if r.set == nil {
+		check.Disable()
+		return
+	}
+	check.Enable()
+	check.OnChanged = func(checked bool) {
+		cell.Edit(func(item *T) { r.set(item, checked) })
+	}
+}
+
+type progressCellRenderer[T any] struct {
+	value func(T) float64
+}
+
+// NewProgressRenderer shows a progress bar for a value between 0 and 1
+func NewProgressRenderer[T any](value func(T) float64) CellRenderer[T] {
+	return &progressCellRenderer[T]{value: value}
+}
+
+func (r *progressCellRenderer[T]) Create() fyne.CanvasObject {
+	return widget.NewProgressBar()
+}
+
+func (r *progressCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+	obj.(*widget.ProgressBar).SetValue(r.value(*cell.Item))
+}
+
+type resourceCellRenderer[T any] struct {
+	resource func(T) fyne.Resource
+}
+
+// NewResourceRenderer shows an icon or image, scaled to fit the cell. A nil resource leaves the cell empty.
+func NewResourceRenderer[T any](resource func(T) fyne.Resource) CellRenderer[T] {
+	return &resourceCellRenderer[T]{resource: resource}
+}
+
+func (r *resourceCellRenderer[T]) Create() fyne.CanvasObject {
+	return widget.NewIcon(nil)
+}
+
+func (r *resourceCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+	obj.(*widget.Icon).SetResource(r.resource(*cell.Item))
+}
+
+type hyperlinkCellRenderer[T any] struct {
+	link func(T) *url.URL
+}
+
+// NewHyperlinkRenderer shows the text of the column as a link, opened in the browser when tapped
+func NewHyperlinkRenderer[T any](link func(T) *url.URL) CellRenderer[T] {
+	return &hyperlinkCellRenderer[T]{link: link}
+}
+
+func (r *hyperlinkCellRenderer[T]) Create() fyne.CanvasObject {
+	link := widget.NewHyperlink("", nil)
+	link.Truncation = fyne.TextTruncateEllipsis
+	return link
+}
+
+func (r *hyperlinkCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+
+	link := obj.(*widget.Hyperlink)
+	link.Alignment = cell.Alignment
+	link.URL = r.link(*cell.Item)
+	link.SetText(cell.Text)
+}
+
+type badgeCellRenderer[T any] struct {
+	color func(T) color.Color
+}
+
+// NewBadgeRenderer shows the text of the column on a rounded chip of the colour
+func NewBadgeRenderer[T any](clr func(T) color.Color) CellRenderer[T] {
+	return &badgeCellRenderer[T]{color: clr}
+}
+
+func (r *badgeCellRenderer[T]) Create() fyne.CanvasObject {
+	return newBadge()
+}
+
+func (r *badgeCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+	obj.(*badge).set(cell.Text, r.color(*cell.Item), cell.Alignment)
+}
+
+type wrappedTextCellRenderer[T any] struct{}
+
+// NewWrappedTextRenderer shows the text of the column over several lines, see WithRowHeight
+func NewWrappedTextRenderer[T any]() CellRenderer[T] {
+	return &wrappedTextCellRenderer[T]{}
+}
+
+func (r *wrappedTextCellRenderer[T]) Create() fyne.CanvasObject {
+	label := widget.NewLabel("")
+	label.Wrapping = fyne.TextWrapWord
+	label.Truncation = fyne.TextTruncateClip
+	return label
+}
+
+func (r *wrappedTextCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+
+	label := obj.(*widget.Label)
+	label.Alignment = cell.Alignment
+	label.SetText(cell.Text)
+}
+
+type sparklineCellRenderer[T any] struct {
+	values func(T) []float64
+}
+
+// NewSparklineRenderer draws the values as a small line chart scaled to their range
+func NewSparklineRenderer[T any](values func(T) []float64) CellRenderer[T] {
+	return &sparklineCellRenderer[T]{values: values}
+}
+
+func (r *sparklineCellRenderer[T]) Create() fyne.CanvasObject {
+	return newSparkline()
+}
+
+func (r *sparklineCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+	obj.(*sparkline).setValues(r.values(*cell.Item))
+}
+
+// ==================== badge =======================
+
+type badge struct {
+	widget.BaseWidget
+	chip      *canvas.Rectangle
+	text      *canvas.Text
+	alignment fyne.TextAlign
+}
+
+func newBadge() *badge {
+
+	b := &badge{chip: canvas.NewRectangle(color.Transparent), text: canvas.NewText("", color.Black)}
+	b.chip.CornerRadius = theme.InputRadiusSize()
+	b.text.TextSize = theme.CaptionTextSize()
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *badge) set(text string, clr color.Color, alignment fyne.TextAlign) {
+
+	b.text.Text = text
+	b.text.Color = contrastingColor(clr)
+	b.chip.FillColor = clr
+	b.alignment = alignment
+	b.Refresh()
+}
+
+func (b *badge) CreateRenderer() fyne.WidgetRenderer {
+	return &badgeRenderer{badge: b}
+}
+
+type badgeRenderer struct {
+	badge *badge
+}
+
+func (r *badgeRenderer) Layout(size fyne.Size) {
+
+	padding := theme.InnerPadding()
+	textSize := r.badge.text.MinSize()
+	chipSize := fyne.NewSize(min(textSize.Width+padding, size.Width), textSize.Height+padding/2)
+
+	x := float32(0)
+	switch r.badge.alignment {
+	case fyne.TextAlignCenter:
+		x = (size.Width - chipSize.Width) / 2
+	case fyne.TextAlignTrailing:
+		x = size.Width - chipSize.Width
+	}
+	y := (size.Height - chipSize.Height) / 2
+
+	r.badge.chip.Resize(chipSize)
+	r.badge.chip.Move(fyne.NewPos(x, y))
+	r.badge.text.Resize(textSize)
+	r.badge.text.Move(fyne.NewPos(x+padding/2, y+padding/4))
+}
+
+func (r *badgeRenderer) MinSize() fyne.Size {
+	padding := theme.InnerPadding()
+	return r.badge.text.MinSize().AddWidthHeight(padding, padding/2)
+}
+
+func (r *badgeRenderer) Refresh() {
+	r.Layout(r.badge.Size())
+	canvas.Refresh(r.badge)
+}
+
+func (r *badgeRenderer) Destroy() {}
+
+func (r *badgeRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.badge.chip, r.badge.text}
+}
+
+// contrastingColor is black or white, whichever reads better on the colour
+func contrastingColor(clr color.Color) color.Color {
+
+	if clr == nil {
+		return theme.Color(theme.ColorNameForeground)
+	}
+	r, g, b, _ := clr.RGBA()
+	if 299*r+587*g+114*b > 1000*0x8000 {
+		return color.Black
+	}
+	return color.White
+}
+
+// ==================== sparkline =======================
+
+type sparkline struct {
+	widget.BaseWidget
+	values []float64
+}
+
+func newSparkline() *sparkline {
+	s := &sparkline{}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *sparkline) setValues(values []float64) {
+	s.values = values
+	s.Refresh()
+}
+
+func (s *sparkline) CreateRenderer() fyne.WidgetRenderer {
+	return &sparklineRenderer{line: s}
+}
+
+type sparklineRenderer struct {
+	line     *sparkline
+	segments []fyne.CanvasObject // of *canvas.Line, kept between refreshes
+}
+
+func (r *sparklineRenderer) Layout(size fyne.Size) {
+
+	values := r.line.values
+	for len(r.segments) < len(values)-1 {
+		r.segments = append(r.segments, canvas.NewLine(color.Transparent))
+	}
+	for _, segment := range r.segments[max(len(values)-1, 0):] {
+		segment.Hide()
+	}
+	if len(values) < 2 {
+		return
+	}
+
+	low, high := slices.Min(values), slices.Max(values)
+	padding := theme.Padding()
+	width, height := size.Width-2*padding, size.Height-2*padding
+	pointAt := func(i int) fyne.Position {
+		y := height / 2
+		if high > low {
+			y = height - float32((values[i]-low)/(high-low))*height
+		}
+		return fyne.NewPos(padding+width*float32(i)/float32(len(values)-1), padding+y)
+	}
+
+	clr := theme.Color(theme.ColorNamePrimary)
+	for i, obj := range r.segments[:len(values)-1] {
+		segment := obj.(*canvas.Line)
+		segment.StrokeColor = clr
+		segment.StrokeWidth = 1.5
+		segment.Position1, segment.Position2 = pointAt(i), pointAt(i+1)
+		segment.Show()
+		segment.Refresh()
+	}
+}
+
+func (r *sparklineRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(theme.IconInlineSize()*2, theme.IconInlineSize())
+}
+
+func (r *sparklineRenderer) Refresh() {
+	r.Layout(r.line.Size())
+	canvas.Refresh(r.line)
+}
+
+func (r *sparklineRenderer) Destroy() {}
+
+func (r *sparklineRenderer) Objects() []fyne.CanvasObject {
+	return r.segments
+}
@@ -0,0 +1,171 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+)
+
+// SortKey orders the rows by a column, earlier keys in the sort stack take priority
+type SortKey struct {
+	Column    int
+	Ascending bool
+}
+
+// sortOn makes the column the only sort key, or adds it to the stack when extending.
+// Clicking a column already in use flips its direction.
+func (gt *GenericTable[T]) sortOn(columnIdx int, extend bool) {
+
+	gt.mu.Lock()
+	if !gt.canSortOnLocked(columnIdx) {
+		gt.mu.Unlock()
+		return
+	}
+
+	pos := gt.sortKeyIndexLocked(columnIdx)
+
+	switch {
+	case extend && pos >= 0:
+		gt.sortKeys[pos].Ascending = !gt.sortKeys[pos].Ascending
+	case extend:
+		gt.sortKeys = append(gt.sortKeys, SortKey{Column: columnIdx, Ascending: true})
+	case pos == 0 && len(gt.sortKeys) == 1:
+		gt.sortKeys[0].Ascending = !gt.sortKeys[0].Ascending
+	default:
+		gt.sortKeys = []SortKey{{Column: columnIdx, Ascending: true}}
+	}
+	gt.mu.Unlock()
+
+	gt.resort()
+}
+
+// SetSort replaces the sort stack, an empty one restores the original item order
+func (gt *GenericTable[T]) SetSort(keys []SortKey) {
+
+	gt.mu.Lock()
+	gt.sortKeys = nil
+	for _, key := range keys {
+		if key.Column >= 0 && key.Column < len(gt.columns) && gt.canSortOnLocked(key.Column) && gt.sortKeyIndexLocked(key.Column) < 0 {
+			gt.sortKeys = append(gt.sortKeys, key)
+		}
+	}
+	gt.mu.Unlock()
+
+	gt.resort()
+}
+
+// canSortOnLocked tells whether the column is sortable, paged tables relying on the source to sort
+func (gt *GenericTable[T]) canSortOnLocked(columnIdx int) bool {
+
+	if _, delegated := gt.source.(SortingSource[T]); gt.pager != nil && !delegated {
+		return false
+	}
+	return gt.columns[columnIdx].field.IsSortable()
+}
+
+// SortKeys returns a copy of the current sort stack
+func (gt *GenericTable[T]) SortKeys() []SortKey {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	return append([]SortKey(nil), gt.sortKeys...)
+}
+
+func (gt *GenericTable[T]) resort() {
+
+	// the sort keys are part of the layout
+	defer gt.layoutChanged()
+
+	gt.unselectCells() // the cell marker is tied to a row index, selections follow the items
+
+	gt.mu.Lock()
+	ss, delegated := gt.source.(SortingSource[T])
+	fields := gt.sortFieldsLocked()
+	if !delegated {
+		gt.refreshRowsLocked()
+	}
+	gt.mu.Unlock()
+
+	if delegated {
+		if err := ss.SetSort(fields); err != nil {
+			gt.reportError(err)
+		}
+		gt.reload()
+		return
+	}
+	gt.redraw()
+}
+
+// sortFieldsLocked resolves the sort keys to their fields for sources that sort themselves
+func (gt *GenericTable[T]) sortFieldsLocked() []SortField[T] {
+
+	fields := make([]SortField[T], len(gt.sortKeys))
+	for i, key := range gt.sortKeys {
+		fields[i] = SortField[T]{Field: gt.columns[key.Column].field, Ascending: key.Ascending}
+	}
+	return fields
+}
+
+func (gt *GenericTable[T]) sortKeyIndexLocked(columnIdx int) int {
+
+	for i, key := range gt.sortKeys {
+		if key.Column == columnIdx {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortRowsLocked does a stable sort so items that compare equal on every key keep their dataset order
+func (gt *GenericTable[T]) sortRowsLocked(rows []*T) {
+
+	if _, delegated := gt.source.(SortingSource[T]); delegated || len(gt.sortKeys) == 0 {
+		return
+	}
+
+	comparators := make([]func(a, b T) bool, len(gt.sortKeys))
+	for i, key := range gt.sortKeys {
+		comparators[i] = gt.columns[key.Column].field.LessThan()
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, lt := range comparators {
+			a, b := *rows[i], *rows[j]
+			if !gt.sortKeys[k].Ascending {
+				a, b = b, a
+			}
+			if lt(a, b) {
+				return true
+			}
+			if lt(b, a) {
+				return false
+			}
+		}
+		return false
+	})
+}
+
+// sortIndicator shows the direction, plus the priority when sorting on several columns
+func (gt *GenericTable[T]) sortIndicator(columnIdx int) string {
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	pos := gt.sortKeyIndexLocked(columnIdx)
+	if pos < 0 {
+		return ""
+	}
+
+	arrow := "↓"
+	if gt.sortKeys[pos].Ascending {
+		arrow = "↑"
+	}
+	if len(gt.sortKeys) == 1 {
+		return " " + arrow
+	}
+	return fmt.Sprintf(" %s%d", arrow, pos+1)
+}
+
+func shiftPressed() bool {
+	return currentModifiers()&fyne.KeyModifierShift != 0
+}
@@ -0,0 +1,265 @@
+package table
+
+import (
+	"image/color"
+
+	"github.com/hooperbloob/fyne-components/meta"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+type StatusShape int
+
+const (
+	StatusCircle StatusShape = iota
+	StatusSquare
+	StatusTriangle
+	StatusDiamond
+)
+
+// Status is how a status column shows an item, nothing is drawn without a colour or icon
+type Status struct {
+	Shape   StatusShape
+	Color   color.Color
+	Icon    fyne.Resource // drawn in place of the shape, typically a theme icon
+	Tooltip string        // shown while the pointer rests on the indicator
+}
+
+// glyph is a character resembling the shape, for exports
+func (s Status) glyph() string {
+
+	switch s.Shape {
+	case StatusSquare:
+		return "■"
+	case StatusTriangle:
+		return "▲"
+	case StatusDiamond:
+		return "◆"
+	}
+	return "●"
+}
+
+// NewStatusColumn shows an indicator for the status of each item, placed by the alignment
+func NewStatusColumn[T any](width int, field *meta.FieldDescriptor[T], alignment fyne.TextAlign, status func(T) Status) Column[T] {
+
+	return Column[T]{
+		width:     width,
+		field:     field,
+		alignment: alignment,
+		status:    status,
+		renderer:  &statusCellRenderer[T]{status: status},
+	}
+}
+
+// StatusFor returns the status of the item in a status column
+func (col *Column[T]) StatusFor(item T) Status {
+	if col.status == nil {
+		return Status{}
+	}
+	return col.status(item)
+}
+
+type statusCellRenderer[T any] struct {
+	status func(T) Status
+}
+
+func (r *statusCellRenderer[T]) Create() fyne.CanvasObject {
+	return newStatusIndicator()
+}
+
+func (r *statusCellRenderer[T]) Update(obj fyne.CanvasObject, cell RenderedCell[T]) {
+	obj.(*statusIndicator).set(r.status(*cell.Item), cell.Alignment)
+}
+
+// ==================== indicator =======================
+
+// statusIndicator draws a status, a square area sized to the smaller side of the cell holds the shape
+type statusIndicator struct {
+	widget.BaseWidget
+	status    Status
+	alignment fyne.TextAlign
+	circle    *canvas.Circle
+	square    *canvas.Rectangle
+	polygon   *canvas.Polygon
+	icon      *canvas.Image
+	tooltip   *widget.PopUp
+}
+
+func newStatusIndicator() *statusIndicator {
+
+	si := &statusIndicator{
+		circle:    canvas.NewCircle(color.Transparent),
+		square:    canvas.NewRectangle(color.Transparent),
+		polygon:   canvas.NewPolygon(3, color.Transparent),
+		icon:      canvas.NewImageFromResource(nil),
+		alignment: fyne.TextAlignCenter,
+	}
+	si.icon.FillMode = canvas.ImageFillContain
+	si.ExtendBaseWidget(si)
+	return si
+}
+
+func (si *statusIndicator) set(status Status, alignment fyne.TextAlign) {
+	si.status = status
+	si.alignment = alignment
+	si.Refresh()
+}
+
+func (si *statusIndicator) CreateRenderer() fyne.WidgetRenderer {
+	return &statusIndicatorRenderer{indicator: si}
+}
+
+// MouseIn shows the tooltip next to the pointer
+func (si *statusIndicator) MouseIn(event *desktop.MouseEvent) {
+
+	if si.status.Tooltip == "" {
+		return
+	}
+	cnvs := fyne.CurrentApp().Driver().CanvasForObject(si)
+	if cnvs == nil {
+		return
+	}
+	si.tooltip = widget.NewPopUp(widget.NewLabel(si.status.Tooltip), cnvs)
+	si.tooltip.ShowAtPosition(event.AbsolutePosition.AddXY(theme.Padding(), si.Size().Height/2))
+}
+
+func (si *statusIndicator) MouseMoved(*desktop.MouseEvent) {}
+
+func (si *statusIndicator) MouseOut() {
+	if si.tooltip != nil {
+		si.tooltip.Hide()
+		si.tooltip = nil
+	}
+}
+
+type statusIndicatorRenderer struct {
+	indicator *statusIndicator
+}
+
+func (r *statusIndicatorRenderer) Layout(size fyne.Size) {
+
+	si := r.indicator
+	side := fyne.Min(size.Width, size.Height) * 0.5
+	if si.status.Icon != nil {
+		side = fyne.Min(side*1.4, size.Height) // icons leave a margin around their glyphs
+	}
+
+	x := (size.Width - side) / 2
+	switch si.alignment {
+	case fyne.TextAlignLeading:
+		x = theme.Padding()
+	case fyne.TextAlignTrailing:
+		x = size.Width - side - theme.Padding()
+	}
+	pos := fyne.NewPos(x, (size.Height-side)/2)
+
+	shapeSize := fyne.NewSize(side, side)
+	for _, obj := range r.Objects() {
+		obj.Resize(shapeSize)
+		obj.Move(pos)
+	}
+	inset := side * 0.15 // a square looks larger than a circle of the same width
+	r.indicator.square.Resize(fyne.NewSize(side-2*inset, side-2*inset))
+	r.indicator.square.Move(pos.AddXY(inset, inset))
+}
+
+func (r *statusIndicatorRenderer) MinSize() fyne.Size {
+	return fyne.NewSquareSize(theme.IconInlineSize())
+}
+
+func (r *statusIndicatorRenderer) Refresh() {
+
+	si := r.indicator
+	status := si.status
+
+	for _, obj := range r.Objects() {
+		obj.Hide()
+	}
+	switch {
+	case status.Icon != nil:
+		si.icon.Resource = status.Icon
+		si.icon.Show()
+	case status.Color == nil:
+	case status.Shape == StatusSquare:
+		si.square.FillColor = status.Color
+		si.square.Show()
+	case status.Shape == StatusTriangle || status.Shape == StatusDiamond:
+		si.polygon.Sides = 3
+		if status.Shape == StatusDiamond {
+			si.polygon.Sides = 4
+		}
+		si.polygon.FillColor = status.Color
+		si.polygon.Show()
+	default:
+		si.circle.FillColor = status.Color
+		si.circle.Show()
+	}
+
+	r.Layout(si.Size())
+	for _, obj := range r.Objects() {
+		obj.Refresh()
+	}
+}
+
+func (r *statusIndicatorRenderer) Destroy() {}
+
+func (r *statusIndicatorRenderer) Objects() []fyne.CanvasObject {
+	si := r.indicator
+	return []fyne.CanvasObject{si.circle, si.square, si.polygon, si.icon}
+}
+
+// ==================== legend =======================
+
+// LegendEntry explains what a status means
+type LegendEntry struct {
+	Status  Status
+	Meaning string
+}
+
+// StatusLegend lists statuses next to what they mean
+type StatusLegend struct {
+	widget.BaseWidget
+	content *fyne.Container
+}
+
+func NewStatusLegend(entries ...LegendEntry) *StatusLegend {
+
+	legend := &StatusLegend{content: container.NewHBox()}
+	legend.SetEntries(entries)
+	legend.ExtendBaseWidget(legend)
+	return legend
+}
+
+// SetEntries replaces what the legend lists
+func (l *StatusLegend) SetEntries(entries []LegendEntry) {
+
+	var objects []fyne.CanvasObject
+	for _, entry := range entries {
+		indicator := newStatusIndicator()
+		indicator.set(entry.Status, fyne.TextAlignCenter)
+		objects = append(objects, indicator, widget.NewLabel(entry.Meaning))
+	}
+	l.content.Objects = objects
+	l.content.Refresh()
+}
+
+func (l *StatusLegend) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(l.content)
+}
+
+// WithLegend shows a legend of the statuses below the table, none hides it
+func (tc *TableContainer[T]) WithLegend(entries ...LegendEntry) *TableContainer[T] {
+
+	tc.legend.SetEntries(entries)
+	if len(entries) == 0 {
+		tc.legend.Hide()
+	} else {
+		tc.legend.Show()
+	}
+	return tc
+}
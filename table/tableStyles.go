@@ -0,0 +1,140 @@
+package table
+
+import (
+	"image/color"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// CellStyle is how a rule changes the look of cells, colours left nil keep those of earlier rules or the theme
+type CellStyle struct {
+	Background    color.Color
+	Foreground    color.Color
+	Bold          bool
+	Italic        bool
+	StrikeThrough bool
+}
+
+// over lays the style over the one below, its colours win where set and the text styles add up
+func (s CellStyle) over(below CellStyle) CellStyle {
+
+	if s.Background == nil {
+		s.Background = below.Background
+	}
+	if s.Foreground == nil {
+		s.Foreground = below.Foreground
+	}
+	s.Bold = s.Bold || below.Bold
+	s.Italic = s.Italic || below.Italic
+	s.StrikeThrough = s.StrikeThrough || below.StrikeThrough
+	return s
+}
+
+// StyleRule styles the cells of the items it matches, later rules are laid over earlier ones
+type StyleRule[T any] struct {
+	When      func(T) bool                                      // nil matches every item
+	Columns   []string                                          // by column ID, see Column.ID, empty for all
+	Style     CellStyle                                         // with a light theme, and a dark one unless Dark is set
+	Dark      *CellStyle                                        // with a dark theme, optional
+	StyleFunc func(item T, variant fyne.ThemeVariant) CellStyle // computes the style per item in place of Style and Dark
+}
+
+func (rule *StyleRule[T]) applies(item T, columnID string) bool {
+	return (len(rule.Columns) == 0 || slices.Contains(rule.Columns, columnID)) && (rule.When == nil || rule.When(item))
+}
+
+func (rule *StyleRule[T]) styleFor(item T, variant fyne.ThemeVariant) CellStyle {
+
+	switch {
+	case rule.StyleFunc != nil:
+		return rule.StyleFunc(item, variant)
+	case variant == theme.VariantDark && rule.Dark != nil:
+		return *rule.Dark
+	}
+	return rule.Style
+}
+
+// HeatMapRule colours the background of the column by where the value falls between low and high,
+// blended with the theme background so the text stays readable with either variant
+func HeatMapRule[T any](columnID string, value func(T) float64, low, high float64, lowColor, highColor color.Color) StyleRule[T] {
+
+	return StyleRule[T]{
+		Columns: []string{columnID},
+		StyleFunc: func(item T, variant fyne.ThemeVariant) CellStyle {
+
+			fraction := 0.5
+			if high > low {
+				fraction = min(max((value(item)-low)/(high-low), 0), 1)
+			}
+			background := theme.Current().Color(theme.ColorNameBackground, variant)
+			return CellStyle{Background: blend(background, blend(lowColor, highColor, fraction), 0.6)}
+		},
+	}
+}
+
+// blend mixes the colours, fraction 0 being all from and 1 all to
+func blend(from, to color.Color, fraction float64) color.Color {
+
+	a := color.NRGBAModel.Convert(from).(color.NRGBA)
+	b := color.NRGBAModel.Convert(to).(color.NRGBA)
+	mix := func(x, y uint8) uint8 { return uint8(float64(x) + (float64(y)-float64(x))*fraction + 0.5) }
+	return color.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
+
+// WithStyleRules adds rules styling the cells
+func (gt *GenericTable[T]) WithStyleRules(rules ...StyleRule[T]) *GenericTable[T] {
+
+	gt.mu.Lock()
+	gt.styleRules = append(gt.styleRules, rules...)
+	gt.mu.Unlock()
+
+	gt.redraw()
+	return gt
+}
+
+// SetStyleRules replaces the rules styling the cells, nil removes them
+func (gt *GenericTable[T]) SetStyleRules(rules []StyleRule[T]) {
+
+	gt.mu.Lock()
+	gt.styleRules = slices.Clone(rules)
+	gt.mu.Unlock()
+
+	gt.redraw()
+}
+
+// WithZebra shades every other row
+func (gt *GenericTable[T]) WithZebra(zebra bool) *GenericTable[T] {
+
+	gt.mu.Lock()
+	gt.zebra = zebra
+	gt.mu.Unlock()
+
+	gt.redraw()
+	return gt
+}
+
+// cellStyle combines the zebra stripes and the rules that apply to the cell
+func (gt *GenericTable[T]) cellStyle(item *T, row int, columnID string) CellStyle {
+
+	gt.mu.Lock()
+	rules, zebra := gt.styleRules, gt.zebra
+	gt.mu.Unlock()
+
+	var style CellStyle
+	if zebra && row%2 == 1 {
+		style.Background = theme.Color(theme.ColorNameHover)
+	}
+	if len(rules) == 0 {
+		return style
+	}
+
+	variant := fyne.CurrentApp().Settings().ThemeVariant()
+	for i := range rules {
+		if rules[i].applies(*item, columnID) {
+			style = rules[i].styleFor(*item, variant).over(style)
+		}
+	}
+	return style
+}
@@ -0,0 +1,207 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/hooperbloob/fyne-components/xlsx"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// approximate width of a character in the default spreadsheet font, for converting column widths
+const xlsxCharWidth = 7
+
+// XLSXExporter writes an Excel workbook keeping numbers, booleans and dates as typed cells,
+// with bold headers and the column widths of the table
+type XLSXExporter[T any] struct {
+	SheetName string
+}
+
+func (XLSXExporter[T]) Name() string      { return "Excel" }
+func (XLSXExporter[T]) Extension() string { return "xlsx" }
+
+func (xe XLSXExporter[T]) Export(w io.Writer, table *GenericTable[T], opts ExportOptions) error {
+
+	columns := table.exportColumns(opts.Columns)
+
+	sheet := xlsx.Sheet{
+		Name:       xe.SheetName,
+		Widths:     make([]float64, len(columns)),
+		BoldHeader: opts.Headers,
+	}
+	for i, col := range columns {
+		sheet.Widths[i] = float64(col.width) / xlsxCharWidth
+	}
+
+	if opts.Headers {
+		header := make([]any, len(columns))
+		for i, col := range columns {
+			header[i] = col.field.Label
+		}
+		sheet.Rows = append(sheet.Rows, header)
+	}
+
+	for _, item := range table.ItemsIn(opts.Scope) {
+		row := make([]any, len(columns))
+		for i, col := range columns {
+			row[i] = cellValueFor(col, *item)
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+
+	return xlsx.Write(w, sheet)
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// cellValueFor narrows the typed value of the column to what a spreadsheet cell can hold
+func cellValueFor[T any](col Column[T], item T) any {
+
+	value := col.field.Value(item)
+	if value == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Type() == timeType {
+		return rv.Interface()
+	}
+	if stringer, ok := rv.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	switch kind := rv.Kind(); {
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return rv.Int()
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		return rv.Uint()
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return rv.Float()
+	case kind == reflect.Bool:
+		return rv.Bool()
+	}
+	return col.StringValueFor(item)
+}
+
+// ImportXLSX builds new items from the first sheet of a workbook, matching columns by a header row
+// or by position, and validating each row
+func (gt *GenericTable[T]) ImportXLSX(r io.ReaderAt, size int64) ([]PastedRow[T], error) {
+
+	sheetRows, err := xlsx.Read(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(sheetRows) == 0 {
+		return nil, fmt.Errorf("The sheet is empty")
+	}
+
+	first := make([]string, len(sheetRows[0]))
+	for i, value := range sheetRows[0] {
+		first[i] = cellText(value)
+	}
+	mapping, hasHeader := gt.columnMapping(first)
+	line := 1
+	if hasHeader {
+		sheetRows = sheetRows[1:]
+		line++
+	}
+
+	rows := make([]PastedRow[T], 0, len(sheetRows))
+	for i, values := range sheetRows {
+		texts := make([]string, len(values))
+		for c, value := range values {
+			texts[c] = cellText(value)
+		}
+		item, err := gt.itemFromCells(gt.newItemFunc(), values, mapping)
+		rows = append(rows, PastedRow[T]{Line: line + i, Values: texts, Item: item, Err: err})
+	}
+	return rows, nil
+}
+
+func (gt *GenericTable[T]) itemFromCells(item T, values []any, mapping []int) (T, error) {
+
+	for i, value := range values {
+		if i >= len(mapping) || mapping[i] < 0 || mapping[i] >= len(gt.columns) {
+			continue
+		}
+		col := gt.columns[mapping[i]]
+		if col.IsIcon() || !col.field.IsEditable() {
+			continue
+		}
+		if err := col.field.SetValue(&item, value); err != nil {
+			return item, err
+		}
+	}
+	return item, gt.Validate(item)
+}
+
+func cellText(value any) string {
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.DateTime)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// ShowImportDialog asks for a workbook and previews its rows before appending them
+func (tc *TableContainer[T]) ShowImportDialog() {
+
+	openDialog := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, tc.window)
+			return
+		}
+		if file == nil {
+			return // cancelled
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			dialog.ShowError(err, tc.window)
+			return
+		}
+
+		rows, err := tc.table.ImportXLSX(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			dialog.ShowError(err, tc.window)
+			return
+		}
+
+		tc.showRowsPreview("Import", rows, nil, func(rows []PastedRow[T]) {
+			tc.showError(tc.table.inHistoryGroup("Import", func() error {
+				for _, row := range rows {
+					if row.Err == nil {
+						item := row.Item
+						if err := tc.table.InsertItem(&item); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}))
+		})
+	}, tc.window)
+
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".xlsx"}))
+	openDialog.Show()
+}
@@ -0,0 +1,70 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/hooperbloob/fyne-components/xlsx"
+)
+
+type contact struct {
+	Name  string `meta:"editable"`
+	Zip   string `meta:"editable"`
+	Phone string `meta:"editable"`
+	Age   int    `meta:"editable"`
+}
+
+func TestImportNumbersAsText(t *testing.T) {
+
+	test.NewApp()
+	columns, err := ColumnsFor[contact]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTable := func(items []*contact) *GenericTable[contact] {
+		gt := NewGenericTable(columns, func() contact { return contact{} })
+		gt.SetData(items)
+		return gt
+	}
+
+	// a sheet typed in by hand, with the codes and numbers stored as numbers
+	var buf bytes.Buffer
+	err = xlsx.Write(&buf, xlsx.Sheet{Rows: [][]any{
+		{"Name", "Zip", "Phone", "Age"},
+		{"Alice", 8001, 4915112345678.0, 30},
+		{"Bob", "01234", true, 25.0},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []contact{{"Alice", "8001", "4915112345678", 30}, {"Bob", "01234", "true", 25}}
+	checkImport(t, newTable(nil), buf.Bytes(), want)
+
+	// exported by the table and read back
+	exported := newTable([]*contact{&want[0], &want[1]})
+	buf.Reset()
+	if err := (XLSXExporter[contact]{}).Export(&buf, exported, ExportOptions{Scope: AllRows, Headers: true}); err != nil {
+		t.Fatal(err)
+	}
+	checkImport(t, newTable(nil), buf.Bytes(), want)
+}
+
+func checkImport(t *testing.T, gt *GenericTable[contact], data []byte, want []contact) {
+
+	t.Helper()
+	rows, err := gt.ImportXLSX(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if row.Err != nil {
+			t.Errorf("line %d: %v", row.Line, row.Err)
+		} else if row.Item != want[i] {
+			t.Errorf("line %d: got %+v, want %+v", row.Line, row.Item, want[i])
+		}
+	}
+}
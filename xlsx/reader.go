@@ -0,0 +1,303 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Read returns the rows of the first worksheet. Numbers come back as float64, booleans as bool,
+// text as string and numbers formatted as dates as time.Time. Missing cells are nil.
+func Read(r io.ReaderAt, size int64) ([][]any, error) {
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+
+	var dateStyles map[int]bool
+	if f, ok := files["xl/styles.xml"]; ok {
+		if dateStyles, err = readDateStyles(f); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: missing worksheet %s", sheetPath)
+	}
+	return readSheet(f, shared, dateStyles)
+}
+
+func decodePart(f *zip.File, into any) error {
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(into)
+}
+
+// firstSheetPath follows the workbook relationships to the first sheet
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("xlsx: not a workbook")
+	}
+	var workbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodePart(wbFile, &workbook); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(workbook.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodePart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].ID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return fallback, nil
+}
+
+// richText covers both plain and run formatted strings
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt richText) String() string {
+
+	if len(rt.Runs) == 0 {
+		return rt.Text
+	}
+	var sb strings.Builder
+	for _, run := range rt.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := decodePart(f, &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.String()
+	}
+	return shared, nil
+}
+
+// readDateStyles finds the cell formats that show numbers as dates or times
+func readDateStyles(f *zip.File) (map[int]bool, error) {
+
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := decodePart(f, &styles); err != nil {
+		return nil, err
+	}
+
+	customDates := map[int]bool{}
+	for _, numFmt := range styles.NumFmts {
+		customDates[numFmt.ID] = isDateFormat(numFmt.Code)
+	}
+
+	dateStyles := map[int]bool{}
+	for i, xf := range styles.CellXfs {
+		id := xf.NumFmtID
+		if (id >= 14 && id <= 22) || (id >= 45 && id <= 47) || customDates[id] {
+			dateStyles[i] = true
+		}
+	}
+	return dateStyles, nil
+}
+
+// isDateFormat looks for date or time placeholders outside quoted and bracketed sections
+func isDateFormat(code string) bool {
+
+	inQuote, inBracket := false, false
+	for _, r := range strings.ToLower(code) {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '[':
+			inBracket = true
+		case r == ']':
+			inBracket = false
+		case inBracket:
+		case strings.ContainsRune("dmyhs", r):
+			return true
+		}
+	}
+	return false
+}
+
+type xmlCell struct {
+	Ref    string   `xml:"r,attr"`
+	Type   string   `xml:"t,attr"`
+	Style  int      `xml:"s,attr"`
+	Value  string   `xml:"v"`
+	Inline richText `xml:"is"`
+}
+
+func readSheet(f *zip.File, shared []string, dateStyles map[int]bool) ([][]any, error) {
+
+	var sheet struct {
+		Rows []struct {
+			Num   int       `xml:"r,attr"`
+			Cells []xmlCell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodePart(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]any
+	for i, row := range sheet.Rows {
+		rowIdx := i
+		if row.Num > 0 {
+			rowIdx = row.Num - 1
+		}
+		if rowIdx >= maxRows {
+			return nil, fmt.Errorf("xlsx: row %d is beyond the last row %d", rowIdx+1, maxRows)
+		}
+		for len(rows) <= rowIdx {
+			rows = append(rows, nil)
+		}
+
+		var values []any
+		for c, cell := range row.Cells {
+			col := c
+			if cell.Ref != "" {
+				ref, ok := columnOf(cell.Ref)
+				if !ok {
+					return nil, fmt.Errorf("xlsx: bad cell reference %q", cell.Ref)
+				}
+				col = ref
+			}
+			if col >= maxColumns {
+				return nil, fmt.Errorf("xlsx: cell %s is beyond the last column %s", cell.Ref, ColumnName(maxColumns-1))
+			}
+			for len(values) <= col {
+				values = append(values, nil)
+			}
+			value, err := cellValue(cell, shared, dateStyles)
+			if err != nil {
+				return nil, fmt.Errorf("xlsx: cell %s: %v", cell.Ref, err)
+			}
+			values[col] = value
+		}
+		rows[rowIdx] = values
+	}
+	return rows, nil
+}
+
+func cellValue(cell xmlCell, shared []string, dateStyles map[int]bool) (any, error) {
+
+	switch cell.Type {
+	case "inlineStr":
+		return cell.Inline.String(), nil
+	case "s":
+		idx, err := strconv.Atoi(cell.Value)
+		if err != nil || idx < 0 || idx >= len(shared) {
+			return nil, fmt.Errorf("bad shared string %q", cell.Value)
+		}
+		return shared[idx], nil
+	case "str", "e":
+		return cell.Value, nil
+	case "b":
+		return cell.Value == "1", nil
+	}
+
+	if cell.Value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(cell.Value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if dateStyles[cell.Style] {
+		return TimeFromSerial(number), nil
+	}
+	return number, nil
+}
+
+// the size of a worksheet, the last cell being XFD1048576
+const (
+	maxRows    = 1 << 20
+	maxColumns = 1 << 14
+)
+
+// columnOf returns the zero based column of an A1 style reference, which has at most 3 letters
+func columnOf(ref string) (int, bool) {
+
+	col := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		if letters == 3 {
+			return 0, false
+		}
+		col = col*26 + int(r-'A'+1)
+		letters++
+	}
+	return col - 1, letters > 0
+}
@@ -0,0 +1,289 @@
+// Package xlsx reads and writes single sheet Office Open XML spreadsheets without external dependencies.
+//
+// Cell values are nil, string, bool, time.Time or any Go integer or float type.
+// Times are stored as date serials with a date format so spreadsheets show them as dates.
+// Integers a float64 can't hold exactly, beyond ±2^53, are stored as text to keep their digits.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sheet is the content of a worksheet
+type Sheet struct {
+	Name       string
+	Widths     []float64 // column widths in characters, 0 leaves the default
+	BoldHeader bool      // show the first row in bold
+	Rows       [][]any
+}
+
+// style indices into the cellXfs of styles.xml
+const (
+	styleDefault = iota
+	styleBold
+	styleDate
+)
+
+// excelEpoch is day zero of the 1900 date system, offset to absorb Excel's phantom 29 Feb 1900
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Write writes the sheet as an xlsx workbook
+func Write(w io.Writer, sheet Sheet) error {
+
+	name := sheet.Name
+	if name == "" {
+		name = "Sheet1"
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		path    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(name))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.path)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := writeSheet(f, sheet); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeSheet(w io.Writer, sheet Sheet) error {
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	bw.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(sheet.Widths) > 0 {
+		bw.WriteString("<cols>")
+		for i, width := range sheet.Widths {
+			if width > 0 {
+				fmt.Fprintf(bw, `<col min="%d" max="%d" width="%.2f" customWidth="1"/>`, i+1, i+1, width)
+			}
+		}
+		bw.WriteString("</cols>")
+	}
+
+	bw.WriteString("<sheetData>")
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(bw, `<row r="%d">`, r+1)
+		for c, value := range row {
+			style := styleDefault
+			if r == 0 && sheet.BoldHeader {
+				style = styleBold
+			}
+			if err := writeCell(bw, CellRef(r, c), value, style); err != nil {
+				return err
+			}
+		}
+		bw.WriteString("</row>")
+	}
+	bw.WriteString("</sheetData></worksheet>")
+	return bw.Flush()
+}
+
+func writeCell(bw *bufio.Writer, ref string, value any, style int) error {
+
+	styleAttr := ""
+	if style != styleDefault {
+		styleAttr = fmt.Sprintf(` s="%d"`, style)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		fmt.Fprintf(bw, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, escape(v))
+	case bool:
+		b := 0
+		if v {
+			b = 1
+		}
+		fmt.Fprintf(bw, `<c r="%s"%s t="b"><v>%d</v></c>`, ref, styleAttr, b)
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		if style == styleDefault {
+			styleAttr = fmt.Sprintf(` s="%d"`, styleDate)
+		}
+		fmt.Fprintf(bw, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, formatNumber(DateSerial(v)))
+	default:
+		if text, ok := inexactInteger(v); ok {
+			fmt.Fprintf(bw, `<c r="%s"%s t="inlineStr"><is><t>%s</t></is></c>`, ref, styleAttr, text)
+			return nil
+		}
+		number, ok := toFloat(v)
+		if !ok {
+			return fmt.Errorf("xlsx: unsupported cell value %T at %s", value, ref)
+		}
+		fmt.Fprintf(bw, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, formatNumber(number))
+	}
+	return nil
+}
+
+// maxExact is the largest integer magnitude from which a float64 still holds every integer
+const maxExact = 1 << 53
+
+// inexactInteger returns the digits of an integer too large to become a float64 unchanged
+func inexactInteger(value any) (string, bool) {
+
+	switch v := value.(type) {
+	case int:
+		return inexactInteger(int64(v))
+	case int64:
+		if v > maxExact || v < -maxExact {
+			return strconv.FormatInt(v, 10), true
+		}
+	case uint:
+		return inexactInteger(uint64(v))
+	case uint64:
+		if v > maxExact {
+			return strconv.FormatUint(v, 10), true
+		}
+	}
+	return "", false
+}
+
+func toFloat(value any) (float64, bool) {
+
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func formatNumber(number float64) string {
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return "0"
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// DateSerial converts a time into Excel's day count, with the time of day as the fraction
+func DateSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+// TimeFromSerial converts Excel's day count back to a time, in UTC as spreadsheets have no zones
+func TimeFromSerial(serial float64) time.Time {
+	days := math.Floor(serial)
+	nanos := math.Round((serial - days) * 24 * float64(time.Hour) / float64(time.Millisecond))
+	return excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(nanos) * time.Millisecond)
+}
+
+// CellRef returns the A1 style reference of the zero based row and column
+func CellRef(row, col int) string {
+	return ColumnName(col) + strconv.Itoa(row+1)
+}
+
+// ColumnName returns the letters of the zero based column, e.g. 0 is A and 26 is AA
+func ColumnName(col int) string {
+
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func escape(text string) string {
+
+	// drop the control characters XML can't carry
+	text = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, text)
+	return xmlEscaper.Replace(text)
+}
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// styles hold the default, bold and date cell formats in that order
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,143 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	born := time.Date(1984, 2, 29, 13, 45, 30, 0, time.UTC)
+	sheet := Sheet{
+		Name:       "People & <Things>",
+		Widths:     []float64{20, 0, 10},
+		BoldHeader: true,
+		Rows: [][]any{
+			{"Name", "Age", "Born", "Member", "ID"},
+			{"Alice \"Al\" <Smith>", 30, born, true, int64(math.MaxInt64)},
+			{"  padded  ", 2.5, time.Time{}, false, uint64(math.MaxUint64)},
+			{nil, int8(-7), nil, nil, int64(-1 << 53)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, sheet); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]any{
+		{"Name", "Age", "Born", "Member", "ID"},
+		{"Alice \"Al\" <Smith>", 30.0, born, true, "9223372036854775807"},
+		{"  padded  ", 2.5, nil, false, "18446744073709551615"},
+		{nil, -7.0, nil, nil, -9007199254740992.0},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for r := range want {
+		for c := range want[r] {
+			var got any
+			if c < len(rows[r]) {
+				got = rows[r][c]
+			}
+			if !reflect.DeepEqual(got, want[r][c]) {
+				t.Errorf("cell %s: got %#v, want %#v", CellRef(r, c), got, want[r][c])
+			}
+		}
+	}
+}
+
+func TestUnsupportedValue(t *testing.T) {
+
+	err := Write(&bytes.Buffer{}, Sheet{Rows: [][]any{{struct{}{}}}})
+	if err == nil {
+		t.Fatal("expected an error for a struct value")
+	}
+}
+
+func TestDateSerial(t *testing.T) {
+
+	for _, tm := range []time.Time{
+		time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+	} {
+		if back := TimeFromSerial(DateSerial(tm)); !back.Equal(tm) {
+			t.Errorf("%v came back as %v", tm, back)
+		}
+	}
+	if serial := DateSerial(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)); serial != 61 {
+		t.Errorf("1 Mar 1900 is serial %v, want 61", serial)
+	}
+}
+
+func TestOversizedRef(t *testing.T) {
+
+	for _, sheetData := range []string{
+		`<row r="1048577"><c r="A1048577"><v>1</v></c></row>`,
+		`<row r="1000000000"><c><v>1</v></c></row>`,
+		`<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+		`<row r="1"><c r="AAAAAAAAAAAAAAAAAAAAAAAAA1"><v>1</v></c></row>`,
+	} {
+		data := withSheetData(t, sheetData)
+		if _, err := Read(bytes.NewReader(data), int64(len(data))); err == nil {
+			t.Errorf("expected an error for %s", sheetData)
+		}
+	}
+
+	data := withSheetData(t, `<row r="1048576"><c r="XFD1048576"><v>1</v></c></row>`)
+	rows, err := Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != maxRows || len(rows[maxRows-1]) != maxColumns {
+		t.Errorf("the last cell came back in a %d row sheet", len(rows))
+	}
+}
+
+// withSheetData returns a workbook whose worksheet holds the rows
+func withSheetData(t *testing.T, sheetData string) []byte {
+
+	var written bytes.Buffer
+	if err := Write(&written, Sheet{}); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(written.Bytes()), int64(written.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			_, err = io.WriteString(w, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+
+				sheetData+`</sheetData></worksheet>`)
+		} else {
+			var rc io.ReadCloser
+			if rc, err = f.Open(); err == nil {
+				_, err = io.Copy(w, rc)
+				rc.Close()
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}